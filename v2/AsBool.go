@@ -5,51 +5,29 @@ import (
 	"strings"
 )
 
-func AsBool() *BoolChain {
-	var chain BoolChain
-	empty := false
-	chain.empty = &empty
-	return &chain
-}
+type BoolChain = Chain[bool]
 
-func (chain *BoolChain) afterSetValue() {
-	// nothing to do
-}
+type boolParser struct{}
 
-func (chain *BoolChain) afterSetStringValue() {
-	// nothing to do
+func AsBool() *BoolChain {
+	return As[bool](boolParser{})
 }
 
-func (chain *BoolChain) afterSetEmpty() {
-	panic(fmt.Errorf("SetEmpty() on a bool has no effect"))
+func (c *Config) AsBool() *BoolChain {
+	return AsBool().UseConfig(c)
 }
 
-func (chain *BoolChain) trySetStringValue(value string) {
-
-	// only proceed if there is a non-empty value
-	value = strings.Trim(value, " ")
-	if len(value) < 1 {
-		return
+func (boolParser) Parse(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "true", "yes", "y", "1":
+		return true, nil
+	case "false", "no", "n", "0":
+		return false, nil
+	default:
+		return false, fmt.Errorf("%q is not a supported bool spelling", value)
 	}
-
-	// set if there is not already a strval
-	if chain.strval == nil {
-		chain.strval = &value
-	}
-
-	// parse
-	value = strings.ToLower(value)
-	if value == "true" || value == "yes" || value == "y" || value == "1" {
-		converted := true
-		chain.value = &converted
-	}
-	if value == "false" || value == "no" || value == "n" || value == "0" {
-		converted := false
-		chain.value = &converted
-	}
-
 }
 
-func (chain *BoolChain) isEmpty(value bool) bool {
+func (boolParser) IsEmpty(value bool, empty bool) bool {
 	return false
 }
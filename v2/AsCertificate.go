@@ -0,0 +1,99 @@
+package config
+
+import (
+	"crypto/sha1"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// Certificate is a TLS certificate (with its private key) as stored in a Key Vault certificate-backed secret.
+type Certificate struct {
+	tls.Certificate
+}
+
+type CertificateChain = Chain[Certificate]
+
+type certificateParser struct{}
+
+// AsCertificate() starts a chain for a certificate and private key in PEM or base64-encoded PFX format, which is how
+// Key Vault returns the secret for a certificate (ex. https://myvault.vault.azure.net/secrets/my-cert). A value that
+// cannot be parsed is always redacted (see ParseError) because it may contain the private key.
+func AsCertificate() *CertificateChain {
+	chain := As[Certificate](certificateParser{})
+	chain.mask()
+	return chain
+}
+
+func (c *Config) AsCertificate() *CertificateChain {
+	return AsCertificate().UseConfig(c)
+}
+
+func (certificateParser) Parse(value string) (Certificate, error) {
+
+	// PFX
+	if !strings.Contains(value, "-----BEGIN") {
+		pfx, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return Certificate{}, fmt.Errorf("the certificate was not PEM or base64-encoded PFX - %w", err)
+		}
+		key, leaf, chain, err := pkcs12.DecodeChain(pfx, "")
+		if err != nil {
+			return Certificate{}, err
+		}
+		cert := tls.Certificate{Certificate: [][]byte{leaf.Raw}, PrivateKey: key, Leaf: leaf}
+		for _, ca := range chain {
+			cert.Certificate = append(cert.Certificate, ca.Raw)
+		}
+		return Certificate{Certificate: cert}, nil
+	}
+
+	// PEM
+	data := []byte(value)
+	cert, err := tls.X509KeyPair(data, data)
+	if err != nil {
+		return Certificate{}, err
+	}
+	cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return Certificate{}, err
+	}
+
+	return Certificate{Certificate: cert}, nil
+}
+
+func (certificateParser) IsEmpty(value Certificate, empty Certificate) bool {
+	return len(value.Certificate.Certificate) == 0
+}
+
+// X509() returns the leaf certificate or nil if there isn't one.
+func (cert Certificate) X509() *x509.Certificate {
+	return cert.Leaf
+}
+
+// Thumbprint() returns the SHA-1 thumbprint of the leaf certificate as shown in the Azure portal.
+func (cert Certificate) Thumbprint() string {
+	if cert.Leaf == nil {
+		return ""
+	}
+	sum := sha1.Sum(cert.Leaf.Raw)
+	return strings.ToUpper(fmt.Sprintf("%x", sum))
+}
+
+// String() is used by Print() so the certificate content is never shown.
+func (cert Certificate) String() string {
+	return cert.Masked()
+}
+
+// Masked() is used by PrintMasked() to show the thumbprint and expiry rather than "(set)".
+func (cert Certificate) Masked() string {
+	if cert.Leaf == nil {
+		return "(not-set)"
+	}
+	return fmt.Sprintf("(thumbprint: %s, expires: %s)", cert.Thumbprint(), cert.Leaf.NotAfter.UTC().Format(time.RFC3339))
+}
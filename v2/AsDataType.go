@@ -2,97 +2,395 @@ package config
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
-	"os"
+	"reflect"
 	"strings"
-
-	"github.com/cheekybits/genny/generic"
 )
 
-//go:generate go run github.com/cheekybits/genny -in=$GOFILE -out=gen-$GOFILE gen "DataType=string,int,float64,bool,Slice,time.Duration"
+// ErrRequired is recorded by Require() and RequireIf() when a chain is in NoPanic() mode.
+var ErrRequired = errors.New("REQUIRED but not provided")
+
+// ParseError describes a value that was provided but could not be parsed into the datatype of the chain.
+// Secrets (ex. resolved from Key Vault or printed with PrintMasked()) are Redacted so the Value is never shown.
+type ParseError struct {
+	Key      string
+	Value    string
+	Type     string
+	Reason   error
+	Redacted bool
+}
 
-type DataType generic.Type
+func (err *ParseError) Error() string {
+	if err.Redacted {
+		return fmt.Sprintf("%s could not be parsed as %s - %v", err.Key, err.Type, err.Reason)
+	}
+	return fmt.Sprintf("%s could not be parsed from %q as %s - %v", err.Key, err.Value, err.Type, err.Reason)
+}
 
-type DataTypeChain struct {
-	IChain
+// quotedValue() is the value as it should be shown by a Printer.
+func (err *ParseError) quotedValue() string {
+	if err.Redacted {
+		return "(redacted)"
+	}
+	return fmt.Sprintf("%q", err.Value)
+}
+
+// redact() removes the value from the error and its reason (ex. strconv errors include the value).
+func (err *ParseError) redact() {
+	if err.Redacted {
+		return
+	}
+	err.Reason = &redactedError{err: err.Reason, value: err.Value}
+	err.Value = "(redacted)"
+	err.Redacted = true
+}
+
+type redactedError struct {
+	err   error
+	value string
+}
+
+func (err *redactedError) Error() string {
+	return strings.ReplaceAll(err.err.Error(), err.value, "(redacted)")
+}
+
+func (err *redactedError) Unwrap() error {
+	return err.err
+}
+
+func (err *ParseError) Unwrap() error {
+	return err.Reason
+}
+
+type Chain[T any] struct {
+	parser   Parser[T]
 	key      *string
 	strval   *string
-	value    *DataType
-	empty    *DataType
-	metadata map[string]interface{}
+	value    *T
+	empty    *T
+	noPanic  bool
+	strict   bool
+	errs     []error
+	parseErr *ParseError
+	masked   bool
+	secret   *SecretInfo
+	source   string
+	origin   string
+	expand   bool
+	cfg      *Config
+	printer  Printer
+	required bool
+	rules    []string
+}
+
+// As() starts a chain for any datatype that can be converted from a string by the supplied parser.
+func As[T any](parser Parser[T]) *Chain[T] {
+	var empty T
+	return &Chain[T]{parser: parser, empty: &empty}
+}
+
+func (chain *Chain[T]) afterSetValue() {
+	// string chains keep strval and value in sync
+	if chain.strval == nil && chain.value != nil {
+		if s, ok := any(*chain.value).(string); ok {
+			chain.strval = &s
+		}
+	}
 }
 
-func (chain *DataTypeChain) SetKey(key string) *DataTypeChain {
+func (chain *Chain[T]) afterSetStringValue() {
+	// string chains keep strval and value in sync
+	if chain.value == nil && chain.strval != nil {
+		if v, ok := any(*chain.strval).(T); ok {
+			chain.value = &v
+		}
+	}
+}
+
+func (chain *Chain[T]) afterSetEmpty() {
+	if _, ok := any(*chain.empty).(bool); ok {
+		panic(fmt.Errorf("SetEmpty() on a bool has no effect"))
+	}
+}
+
+func (chain *Chain[T]) trySetStringValue(value string) {
+	chain.trySetStringValueExplicitly(value, false)
+}
+
+// trySetRawValue() expands (see Expand()) a value as it was read from a source or supplied before it is set. Secrets
+// returned by Resolve() are set with trySetStringValue() so they are never expanded.
+func (chain *Chain[T]) trySetRawValue(ctx context.Context, value string, explicit bool) {
+	if chain.expand {
+		expanded, err := chain.config().expandBySources(ctx, chain.keyOrEmpty(), value)
+		if err != nil {
+			chain.fail(err)
+			return
+		}
+		value = expanded
+	}
+	chain.trySetStringValueExplicitly(value, explicit)
+}
+
+// trySetStringValueExplicitly() sets even an empty value (ex. 0) when explicit is true because it was deliberately supplied.
+func (chain *Chain[T]) trySetStringValueExplicitly(value string, explicit bool) {
+
+	// only proceed if there is a non-empty value (and a strict failure has not already been recorded)
+	value = strings.Trim(value, " ")
+	converted, err := chain.parser.Parse(value)
+	if len(value) < 1 && (err != nil || chain.isEmpty(converted)) {
+		return
+	}
+	if chain.strict && chain.parseErr != nil {
+		return
+	}
+
+	// set if there is not already a strval
+	if chain.strval == nil {
+		chain.strval = &value
+	}
+
+	// remember the first parse failure (Key Vault URLs are expected to be resolved later)
+	if err != nil {
+		if chain.parseErr == nil && !chain.config().isKeyvaultUrl(value) {
+			chain.parseErr = &ParseError{Key: chain.Key(), Value: value, Type: fmt.Sprintf("%T", *chain.empty), Reason: err}
+			if chain.masked || chain.secret != nil {
+				chain.parseErr.redact()
+			}
+			if chain.strict {
+				chain.fail(chain.parseErr)
+			}
+		}
+		return
+	}
+
+	// set if not empty; this replaces a value that could not be parsed (ex. from an earlier source)
+	if explicit || !chain.isEmpty(converted) {
+		chain.value = &converted
+		if chain.parseErr != nil {
+			chain.parseErr = nil
+			chain.strval = &value
+		}
+	}
+
+}
+
+// fail() panics unless the chain is in NoPanic() mode, in which case the error is recorded for Err().
+func (chain *Chain[T]) fail(err error) {
+	if !chain.noPanic {
+		panic(err)
+	}
+	chain.errs = append(chain.errs, err)
+}
+
+func (chain *Chain[T]) isEmpty(value T) bool {
+	return chain.parser.IsEmpty(value, *chain.empty)
+}
+
+// NoPanic() records errors from Require(), RequireIf(), Resolve(), Expand(), and Strict() so they can be returned by Err() or ValueOrError() instead of panicking.
+// EnsureOneOf() rejections are also recorded in this mode.
+func (chain *Chain[T]) NoPanic() *Chain[T] {
+	chain.noPanic = true
+	return chain
+}
+
+// UseConfig() uses the supplied Config (see New()) for sources, Resolve(), and printing instead of Default().
+func (chain *Chain[T]) UseConfig(c *Config) *Chain[T] {
+	chain.cfg = c
+	return chain
+}
+
+func (chain *Chain[T]) config() *Config {
+	if chain.cfg == nil {
+		return defaultConfig
+	}
+	return chain.cfg
+}
+
+// UseSession() registers the chain with a session and puts it in NoPanic() mode.
+func (chain *Chain[T]) UseSession(session *Session) *Chain[T] {
+	session.register(chain)
+	return chain.NoPanic()
+}
+
+// Strict() treats a value that is provided but cannot be parsed as a failure rather than allowing DefaultTo() to replace it.
+// It may be called before or after the value is set.
+func (chain *Chain[T]) Strict() *Chain[T] {
+	if !chain.strict {
+		chain.strict = true
+		chain.rules = append(chain.rules, "strict")
+		if chain.parseErr != nil {
+			chain.fail(chain.parseErr)
+		}
+	}
+	return chain
+}
+
+func (chain *Chain[T]) SetKey(key string) *Chain[T] {
 	chain.key = &key
 	return chain
 }
 
-func (chain *DataTypeChain) SetStringValue(value string) *DataTypeChain {
+func (chain *Chain[T]) SetStringValue(value string) *Chain[T] {
 	chain.strval = &value
+	chain.source = "string"
 	chain.afterSetStringValue()
 	return chain
 }
 
-func (chain *DataTypeChain) SetValue(value DataType) *DataTypeChain {
+func (chain *Chain[T]) SetValue(value T) *Chain[T] {
 	chain.value = &value
+	chain.parseErr = nil
+	chain.source = "value"
 	chain.afterSetValue()
 	return chain
 }
 
-func (chain *DataTypeChain) SetEmpty(value DataType) *DataTypeChain {
+func (chain *Chain[T]) SetEmpty(value T) *Chain[T] {
 	chain.empty = &value
 	chain.afterSetEmpty()
 	return chain
 }
 
-func (chain *DataTypeChain) Clear() *DataTypeChain {
+func (chain *Chain[T]) Clear() *Chain[T] {
 	chain.value = nil
 	chain.afterSetValue()
 	return chain
 }
 
-func (chain *DataTypeChain) TrySetValue(value DataType) *DataTypeChain {
-	if chain.value == nil {
+func (chain *Chain[T]) TrySetValue(value T) *Chain[T] {
+	if chain.value == nil && !(chain.strict && chain.parseErr != nil) {
 		if !chain.isEmpty(value) {
 			chain.value = &value
+			chain.source = "value"
 			chain.afterSetValue()
 		}
 	}
 	return chain
 }
 
-func (chain *DataTypeChain) DefaultTo(value DataType) *DataTypeChain {
-	return chain.TrySetValue(value)
+func (chain *Chain[T]) DefaultTo(value T) *Chain[T] {
+	if chain.value == nil {
+		chain.TrySetValue(value)
+		if chain.value != nil {
+			chain.source = "default"
+		}
+	}
+	return chain
+}
+
+// TrySetByEnv() reads the environment variable or, if it was not set outside of Apply() and Watch(), the value loaded
+// from App Config by the Config of the chain (see AppConfigSource()).
+func (chain *Chain[T]) TrySetByEnv(key string) *Chain[T] {
+	return chain.TrySetBy(chain.config().AppConfigSource(), key).TrySetBy(EnvSource{}, key)
+}
+
+// TrySetBy() reads the key from the supplied source. Errors from the source are handled like Resolve() errors.
+func (chain *Chain[T]) TrySetBy(source Source, key string) *Chain[T] {
+	return chain.TrySetByContext(context.Background(), source, key)
 }
 
-func (chain *DataTypeChain) TrySetByEnv(key string) *DataTypeChain {
+// TrySetByContext() is TrySetBy() with a context that is passed to the source (and to any sources used by Expand()).
+func (chain *Chain[T]) TrySetByContext(ctx context.Context, source Source, key string) *Chain[T] {
 
 	// set the name if not set
 	if chain.key == nil {
 		chain.key = &key
 	}
 
+	chain.trySetBy(ctx, source, key)
+	return chain
+}
+
+func (chain *Chain[T]) trySetBy(ctx context.Context, source Source, key string) {
+
 	// ignore if already set
 	if chain.value == nil {
-		raw, ok := os.LookupEnv(key)
+		raw, ok, err := source.Lookup(ctx, key)
+		if err != nil {
+			chain.fail(fmt.Errorf("%s could not be read - %w", chain.Key(), err))
+			return
+		}
 		if ok {
-			chain.trySetStringValue(raw)
+			_, explicit := source.(explicitSource)
+			previous := chain.strval
+			chain.trySetRawValue(ctx, raw, explicit)
+			if chain.strval != previous {
+				chain.describe(source, key)
+			}
 		}
 	}
 
+}
+
+// TrySetByFile() reads the file (ex. a mounted secret) without trailing newlines if it exists. The path is not used as the key.
+func (chain *Chain[T]) TrySetByFile(path string) *Chain[T] {
+	if chain.value == nil {
+		raw, ok, err := readFile(path)
+		if err != nil {
+			chain.fail(fmt.Errorf("%s could not be read - %w", chain.Key(), err))
+			return chain
+		}
+		if ok {
+			previous := chain.strval
+			chain.trySetRawValue(context.Background(), raw, false)
+			if chain.strval != previous {
+				chain.source, chain.origin = "file", path
+			}
+		}
+	}
+	return chain
+}
+
+// TrySetByFlag() reads the named flag only if it was supplied on the command line, so 0 or false can still override the env.
+// Unlike TrySetByEnv(), the flag name is not used as the key.
+func (chain *Chain[T]) TrySetByFlag(fs *flag.FlagSet, name string) *Chain[T] {
+	chain.trySetBy(context.Background(), FlagSource{FlagSet: fs, Exact: true}, name)
+	return chain
+}
+
+// TrySetBySources() tries each of the registered sources (see UseSources()) in order until a value is set.
+func (chain *Chain[T]) TrySetBySources(key string) *Chain[T] {
+	return chain.TrySetBySourcesContext(context.Background(), key)
+}
+
+// TrySetBySourcesContext() is TrySetBySources() with a context that is passed to each source.
+func (chain *Chain[T]) TrySetBySourcesContext(ctx context.Context, key string) *Chain[T] {
+	for _, source := range chain.config().Sources() {
+		if chain.value != nil {
+			break
+		}
+		chain.TrySetByContext(ctx, source, key)
+	}
 	return chain
 }
 
-func (chain *DataTypeChain) TrySetByString(value string) *DataTypeChain {
-	chain.trySetStringValue(value)
+func (chain *Chain[T]) TrySetByString(value string) *Chain[T] {
+	previous := chain.strval
+	chain.trySetRawValue(context.Background(), value, false)
+	if chain.strval != previous {
+		chain.source, chain.origin = "string", ""
+	}
 	return chain
 }
 
+// describe() records where the value came from for Print() (see PrintedValue).
+func (chain *Chain[T]) describe(source Source, key string) {
+	chain.source, chain.origin = fmt.Sprintf("%T", source), ""
+	if describer, ok := source.(describedSource); ok {
+		chain.source, chain.origin = describer.describe(key)
+	}
+	if chain.source == "env" && chain.config().wasApplied(key) {
+		chain.source = "appconfig"
+	}
+}
+
 // Lookup() will replace the current value even if set if a suitable key/value pair was found.
-func (chain *DataTypeChain) Lookup(lookup map[string]DataType) *DataTypeChain {
+func (chain *Chain[T]) Lookup(lookup map[string]T) *Chain[T] {
 	if chain.strval != nil {
 		key := *chain.strval
-		var val *DataType
+		var val *T
 		if v, ok := lookup[key]; ok {
 			val = &v
 		} else if v, ok := lookup[strings.ToLower(key)]; ok {
@@ -100,19 +398,21 @@ func (chain *DataTypeChain) Lookup(lookup map[string]DataType) *DataTypeChain {
 		}
 		if val != nil {
 			chain.value = val
+			chain.parseErr = nil
 			chain.afterSetValue()
 		}
 	}
 	return chain
 }
 
-func (chain *DataTypeChain) Transform(f func(*DataTypeChain)) *DataTypeChain {
+func (chain *Chain[T]) Transform(f func(*Chain[T])) *Chain[T] {
 	f(chain)
 	return chain
 }
 
 // EnsureOneOf() clears strval and value if strval is not one of the selected options.
-func (chain *DataTypeChain) EnsureOneOf(options ...string) *DataTypeChain {
+func (chain *Chain[T]) EnsureOneOf(options ...string) *Chain[T] {
+	chain.rules = append(chain.rules, fmt.Sprintf("oneof(%s)", strings.Join(options, ", ")))
 
 	// use the value or empty to evaluate
 	strval := chain.StringValue()
@@ -127,6 +427,9 @@ func (chain *DataTypeChain) EnsureOneOf(options ...string) *DataTypeChain {
 
 	// if not found, clear strval and value
 	if !found {
+		if chain.noPanic && chain.strval != nil {
+			chain.errs = append(chain.errs, fmt.Errorf("%s was %q but must be one of %v", chain.Key(), strval, options))
+		}
 		chain.strval = nil
 		chain.value = nil
 	}
@@ -134,62 +437,185 @@ func (chain *DataTypeChain) EnsureOneOf(options ...string) *DataTypeChain {
 	return chain
 }
 
-func (chain *DataTypeChain) Resolve(ctx context.Context) *DataTypeChain {
+// Clamp() requires a parser that implements Comparer; other chains fail (see NoPanic()).
+func (chain *Chain[T]) Clamp(min T, max T) *Chain[T] {
+	chain.rules = append(chain.rules, fmt.Sprintf("clamp(%v, %v)", min, max))
+	comparer, ok := chain.parser.(Comparer[T])
+	if !ok {
+		chain.fail(fmt.Errorf("Clamp() is not supported for %T", *chain.empty))
+		return chain
+	}
+	if comparer.Compare(max, min) < 0 {
+		chain.fail(fmt.Errorf("Clamp() requires max to be >= min"))
+		return chain
+	}
+	if chain.value != nil {
+		if comparer.Compare(*chain.value, min) < 0 {
+			chain.value = &min
+		}
+		if comparer.Compare(*chain.value, max) > 0 {
+			chain.value = &max
+		}
+	}
+	return chain
+}
+
+func (chain *Chain[T]) Resolve(ctx context.Context) *Chain[T] {
 	if chain.strval != nil {
-		val, err := resolve(ctx, *chain.strval)
+		val, info, err := chain.config().resolve(ctx, *chain.strval)
 		if err != nil {
-			panic(err)
+			chain.fail(fmt.Errorf("%s could not be resolved - %w", chain.Key(), err))
+			return chain
 		}
+		chain.secret = info
 		chain.trySetStringValue(val)
+		if info != nil {
+			chain.source = "keyvault"
+		}
 	}
 	return chain
 }
 
-func (chain *DataTypeChain) Print() *DataTypeChain {
-	fmt.Printf("  %s = %v\n", chain.Key(), chain.Value())
+func (chain *Chain[T]) keyvaultUrl() (string, bool) {
+	if chain.strval != nil && chain.config().isKeyvaultUrl(*chain.strval) {
+		return *chain.strval, true
+	}
+	return "", false
+}
+
+func (chain *Chain[T]) applyResolved(val string, info *SecretInfo, err error) {
+	if err != nil {
+		chain.errs = append(chain.errs, err)
+		return
+	}
+	chain.secret = info
+	chain.trySetStringValue(val)
+	if info != nil {
+		chain.source = "keyvault"
+	}
+}
+
+// printed() describes the chain for the Printer.
+func (chain *Chain[T]) printed(value string, masked bool) PrintedValue {
+	printed := PrintedValue{
+		Key:    chain.Key(),
+		Type:   fmt.Sprintf("%T", *chain.empty),
+		Value:  value,
+		Masked: masked,
+		IsSet:  chain.value != nil,
+		Source: chain.source,
+		File:   chain.origin,
+		chain:  chain,
+	}
+	if chain.secret != nil {
+		printed.Version = chain.secret.Version
+	}
+	return printed
+}
+
+// output() returns the printer for the chain (see UsePrinter()) or for its Config.
+func (chain *Chain[T]) output() Printer {
+	if chain.printer != nil {
+		return chain.printer
+	}
+	return chain.config().output()
+}
+
+// UsePrinter() sends Print(), PrintMasked(), and PrintLookup() for this chain to the printer instead of the one for its Config.
+func (chain *Chain[T]) UsePrinter(printer Printer) *Chain[T] {
+	chain.printer = printer
 	return chain
 }
 
-func (chain *DataTypeChain) PrintMasked() *DataTypeChain {
+func (chain *Chain[T]) Print() *Chain[T] {
+	printed := chain.printed(fmt.Sprintf("%v", chain.Value()), false)
+	printed.Ignored = chain.parseErr
+	chain.output().PrintValue(printed)
+	return chain
+}
+
+// PrintMasked() shows "(set)" unless the value is a Key Vault URL or the datatype has a Masked() method to show instead.
+// A value that could not be parsed is redacted from Err() too.
+func (chain *Chain[T]) PrintMasked() *Chain[T] {
+	chain.mask()
 	if chain.value != nil {
-		if v := strings.ToLower(*chain.strval); strings.HasPrefix(v, "https://") && strings.Contains(v, ".vault.azure.net") {
-			fmt.Printf("  %s = %v\n", chain.Key(), *chain.strval)
+		if masker, ok := any(*chain.value).(interface{ Masked() string }); ok {
+			chain.output().PrintValue(chain.printed(masker.Masked(), true))
+		} else if chain.config().isKeyvaultUrl(chain.StringValue()) {
+			chain.output().PrintValue(chain.printed(*chain.strval, true))
 		} else {
-			fmt.Printf("  %s = (set)\n", chain.Key())
+			chain.output().PrintValue(chain.printed("(set)", true))
 		}
 	} else {
-		fmt.Printf("  %s = (not-set)\n", chain.Key())
+		chain.output().PrintValue(PrintedValue{Key: chain.Key(), Type: fmt.Sprintf("%T", *chain.empty), Value: "(not-set)", Masked: true, chain: chain})
 	}
 	return chain
 }
 
-func (chain *DataTypeChain) Require() *DataTypeChain {
-	if chain.value == nil {
-		panic(fmt.Errorf("  %s was REQUIRED but not provided", chain.Key()))
+// mask() treats the chain as a secret so a value that cannot be parsed is never shown.
+func (chain *Chain[T]) mask() {
+	chain.masked = true
+	if chain.parseErr != nil {
+		chain.parseErr.redact()
+	}
+}
+
+// PrintLookup() prints the key from the lookup table that matches the value rather than the value itself.
+func (chain *Chain[T]) PrintLookup(lookup map[string]T) *Chain[T] {
+	val := chain.Value()
+	for k, v := range lookup {
+		if reflect.DeepEqual(v, val) {
+			chain.output().PrintValue(chain.printed(k, false))
+			break
+		}
 	}
 	return chain
 }
 
-func (chain *DataTypeChain) RequireIf(clause bool) *DataTypeChain {
+func (chain *Chain[T]) Require() *Chain[T] {
+	return chain.RequireIf(true)
+}
+
+func (chain *Chain[T]) RequireIf(clause bool) *Chain[T] {
+	if clause {
+		chain.required = true
+	}
 	if clause && chain.value == nil {
-		panic(fmt.Errorf("  %s was REQUIRED but not provided", chain.Key()))
+		if chain.noPanic {
+			chain.fail(fmt.Errorf("%s was %w", chain.Key(), ErrRequired))
+		} else {
+			panic(fmt.Errorf("  %s was REQUIRED but not provided", chain.Key()))
+		}
 	}
 	return chain
 }
 
-func (chain *DataTypeChain) IsKeySet() bool {
+func (chain *Chain[T]) isRequired() bool {
+	return chain.required
+}
+
+func (chain *Chain[T]) validationRules() []string {
+	return append([]string{}, chain.rules...)
+}
+
+// SecretInfo() returns the metadata for the Key Vault secret that was resolved or nil if nothing was resolved.
+func (chain *Chain[T]) SecretInfo() *SecretInfo {
+	return chain.secret
+}
+
+func (chain *Chain[T]) IsKeySet() bool {
 	return chain.key != nil
 }
 
-func (chain *DataTypeChain) IsStringValueSet() bool {
+func (chain *Chain[T]) IsStringValueSet() bool {
 	return chain.strval != nil
 }
 
-func (chain *DataTypeChain) IsValueSet() bool {
+func (chain *Chain[T]) IsValueSet() bool {
 	return chain.value != nil
 }
 
-func (chain *DataTypeChain) Key() string {
+func (chain *Chain[T]) Key() string {
 	if chain.key != nil {
 		return *chain.key
 	} else {
@@ -197,7 +623,14 @@ func (chain *DataTypeChain) Key() string {
 	}
 }
 
-func (chain *DataTypeChain) Value() DataType {
+func (chain *Chain[T]) keyOrEmpty() string {
+	if chain.key != nil {
+		return *chain.key
+	}
+	return ""
+}
+
+func (chain *Chain[T]) Value() T {
 	if chain.value == nil {
 		return *chain.empty
 	} else {
@@ -205,10 +638,23 @@ func (chain *DataTypeChain) Value() DataType {
 	}
 }
 
-func (chain *DataTypeChain) StringValue() string {
+func (chain *Chain[T]) StringValue() string {
 	if chain.strval == nil {
 		return ""
 	} else {
 		return *chain.strval
 	}
 }
+
+// Err() returns all errors recorded while the chain was in NoPanic() mode plus any value that could not be parsed or nil if there were none.
+func (chain *Chain[T]) Err() error {
+	if chain.parseErr != nil && !chain.strict {
+		return errors.Join(append(chain.errs, chain.parseErr)...)
+	}
+	return errors.Join(chain.errs...)
+}
+
+// ValueOrError() is the non-panicking alternative to Value().
+func (chain *Chain[T]) ValueOrError() (T, error) {
+	return chain.Value(), chain.Err()
+}
@@ -1,56 +1,36 @@
 package config
 
 import (
-	"strings"
 	"time"
 )
 
+type TimeDurationChain = Chain[time.Duration]
+
+type durationParser struct{}
+
 func AsDuration() *TimeDurationChain {
-	var chain TimeDurationChain
-	empty := time.Duration(0)
-	chain.empty = &empty
-	return &chain
+	return As[time.Duration](durationParser{})
 }
 
-func (chain *TimeDurationChain) afterSetValue() {
-	// nothing to do
+func (c *Config) AsDuration() *TimeDurationChain {
+	return AsDuration().UseConfig(c)
 }
 
-func (chain *TimeDurationChain) afterSetStringValue() {
-	// nothing to do
+func (durationParser) Parse(value string) (time.Duration, error) {
+	return time.ParseDuration(value)
 }
 
-func (chain *TimeDurationChain) afterSetEmpty() {
-	// nothing to do
+func (durationParser) IsEmpty(value time.Duration, empty time.Duration) bool {
+	return value.Seconds() == empty.Seconds()
 }
 
-func (chain *TimeDurationChain) trySetStringValue(value string) {
-
-	// only proceed if there is a non-empty value
-	value = strings.Trim(value, " ")
-	if len(value) < 1 {
-		return
-	}
-
-	// set if there is not already a strval
-	if chain.strval == nil {
-		chain.strval = &value
-	}
-
-	// attempt to convert to duration
-	converted, err := time.ParseDuration(value)
-	if err != nil {
-		return
-	}
-
-	// set if not empty
-	if converted != *chain.empty {
-		chain.value = &converted
+func (durationParser) Compare(a time.Duration, b time.Duration) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
 	}
-
-}
-
-func (chain *TimeDurationChain) isEmpty(value time.Duration) bool {
-	empty := *chain.empty
-	return value.Seconds() == empty.Seconds()
 }
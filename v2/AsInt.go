@@ -1,83 +1,36 @@
 package config
 
 import (
-	"fmt"
 	"strconv"
-	"strings"
 )
 
-func AsInt() *IntChain {
-	var chain IntChain
-	empty := 0
-	chain.empty = &empty
-	return &chain
-}
+type IntChain = Chain[int]
 
-func (chain *IntChain) afterSetValue() {
-	// nothing to do
-}
+type intParser struct{}
 
-func (chain *IntChain) afterSetStringValue() {
-	// nothing to do
+func AsInt() *IntChain {
+	return As[int](intParser{})
 }
 
-func (chain *IntChain) afterSetEmpty() {
-	// nothing to do
+func (c *Config) AsInt() *IntChain {
+	return AsInt().UseConfig(c)
 }
 
-func (chain *IntChain) trySetStringValue(value string) {
-
-	// only proceed if there is a non-empty value
-	value = strings.Trim(value, " ")
-	if len(value) < 1 {
-		return
-	}
-
-	// set if there is not already a strval
-	if chain.strval == nil {
-		chain.strval = &value
-	}
-
-	// parse
-	converted, err := strconv.Atoi(value)
-	if err != nil {
-		return
-	}
-
-	// set if not empty
-	if converted != *chain.empty {
-		chain.value = &converted
-	}
-
+func (intParser) Parse(value string) (int, error) {
+	return strconv.Atoi(value)
 }
 
-func (chain *IntChain) isEmpty(value int) bool {
-	empty := *chain.empty
+func (intParser) IsEmpty(value int, empty int) bool {
 	return value == empty
 }
 
-func (chain *IntChain) Clamp(min int, max int) *IntChain {
-	if chain.value != nil {
-		if max < min {
-			panic(fmt.Errorf("max must be >= min"))
-		}
-		if *chain.value < min {
-			chain.value = &min
-		}
-		if *chain.value > max {
-			chain.value = &max
-		}
-	}
-	return chain
-}
-
-func (chain *IntChain) PrintLookup(lookup map[string]int) *IntChain {
-	val := chain.Value()
-	for k, v := range lookup {
-		if v == val {
-			fmt.Printf("  %s = %s\n", chain.Key(), k)
-			break
-		}
+func (intParser) Compare(a int, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
 	}
-	return chain
 }
@@ -1,53 +1,33 @@
 package config
 
 import (
+	"fmt"
 	"strings"
 )
 
 type Slice []string
 
-func AsSlice() *SliceChain {
-	var chain SliceChain
-	empty := Slice([]string{})
-	chain.empty = &empty
-	return &chain
-}
+type SliceChain = Chain[Slice]
 
-func (chain *SliceChain) afterSetValue() {
-	// nothing to do
+type sliceParser struct {
+	delimiter string
 }
 
-func (chain *SliceChain) afterSetStringValue() {
-	// nothing to do
+func AsSlice() *SliceChain {
+	chain := As[Slice](&sliceParser{delimiter: ","})
+	empty := Slice([]string{})
+	chain.empty = &empty
+	return chain
 }
 
-func (chain *SliceChain) afterSetEmpty() {
-	// nothing to do
+func (c *Config) AsSlice() *SliceChain {
+	return AsSlice().UseConfig(c)
 }
 
-func (chain *SliceChain) trySetStringValue(value string) {
-
-	// only proceed if there is a non-empty value
-	value = strings.Trim(value, " ")
-	if len(value) < 1 {
-		return
-	}
-
-	// set if there is not already a strval
-	if chain.strval == nil {
-		chain.strval = &value
-	}
-
-	// determine the delimiter
-	delimiter := ","
-	if chain.metadata != nil {
-		if d, ok := chain.metadata["delimiter"].(string); ok {
-			delimiter = d
-		}
-	}
+func (parser *sliceParser) Parse(value string) (Slice, error) {
 
 	// split
-	raw := strings.Split(value, delimiter)
+	raw := strings.Split(value, parser.delimiter)
 
 	// normalize
 	converted := make([]string, 0, len(raw))
@@ -58,23 +38,20 @@ func (chain *SliceChain) trySetStringValue(value string) {
 		}
 	}
 
-	// assign
-	cast := Slice(converted)
-	if !chain.isEmpty(cast) {
-		chain.value = &cast
-	}
-
+	return Slice(converted), nil
 }
 
-func (chain *SliceChain) isEmpty(value Slice) bool {
-	empty := *chain.empty
+func (parser *sliceParser) IsEmpty(value Slice, empty Slice) bool {
 	return areSlicesEqual(value, empty)
 }
 
-func (chain *SliceChain) UseDelimiter(delimiter string) *SliceChain {
-	if chain.metadata == nil {
-		chain.metadata = make(map[string]interface{})
-		chain.metadata["delimiter"] = delimiter
+// UseDelimiter() is only supported on slice chains; other chains fail (see NoPanic()).
+func (chain *Chain[T]) UseDelimiter(delimiter string) *Chain[T] {
+	parser, ok := any(chain.parser).(*sliceParser)
+	if !ok {
+		chain.fail(fmt.Errorf("UseDelimiter() is not supported for %T", *chain.empty))
+		return chain
 	}
+	parser.delimiter = delimiter
 	return chain
 }
@@ -1,72 +1,51 @@
 package config
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
+
+type StringChain = Chain[string]
+
+type stringParser struct{}
 
 func AsString() *StringChain {
-	var chain StringChain
-	empty := ""
-	chain.empty = &empty
-	return &chain
+	return As[string](stringParser{})
 }
 
-func (chain *StringChain) afterSetValue() {
-	if chain.strval == nil && chain.value != nil {
-		chain.strval = chain.value
-	}
+func (c *Config) AsString() *StringChain {
+	return AsString().UseConfig(c)
 }
 
-func (chain *StringChain) afterSetStringValue() {
-	if chain.value == nil && chain.strval != nil {
-		chain.value = chain.strval
-	}
+func (stringParser) Parse(value string) (string, error) {
+	return value, nil
 }
 
-func (chain *StringChain) afterSetEmpty() {
-	// nothing to do
+func (stringParser) IsEmpty(value string, empty string) bool {
+	return value == empty
 }
 
-func (chain *StringChain) trySetStringValue(value string) {
-
-	// make sure it isn't empty
-	value = strings.Trim(value, " ")
-	if value == *chain.empty {
-		return
-	}
-
-	// set if there is not already a strval
-	if chain.strval == nil {
-		chain.strval = &value
-	}
-
-	// set the value
-	chain.value = &value
-
+// ToUpper() changes the casing of strval and value. It is only supported on string chains; other chains fail (see NoPanic()).
+func (chain *Chain[T]) ToUpper() *Chain[T] {
+	return chain.changeCase(strings.ToUpper, "ToUpper()")
 }
 
-func (chain *StringChain) isEmpty(value string) bool {
-	empty := *chain.empty
-	return value == empty
+// ToLower() changes the casing of strval and value. It is only supported on string chains; other chains fail (see NoPanic()).
+func (chain *Chain[T]) ToLower() *Chain[T] {
+	return chain.changeCase(strings.ToLower, "ToLower()")
 }
 
-func (chain *StringChain) ToUpper() *StringChain {
-	if chain.strval != nil {
-		mod := strings.ToUpper(*chain.strval)
-		chain.strval = &mod
-	}
-	if chain.value != nil {
-		mod := strings.ToUpper(*chain.value)
-		chain.value = &mod
+func (chain *Chain[T]) changeCase(change func(string) string, method string) *Chain[T] {
+	if _, ok := any(*chain.empty).(string); !ok {
+		chain.fail(fmt.Errorf("%s is not supported for %T", method, *chain.empty))
+		return chain
 	}
-	return chain
-}
-
-func (chain *StringChain) ToLower() *StringChain {
 	if chain.strval != nil {
-		mod := strings.ToLower(*chain.strval)
+		mod := change(*chain.strval)
 		chain.strval = &mod
 	}
 	if chain.value != nil {
-		mod := strings.ToLower(*chain.value)
+		mod := any(change(any(*chain.value).(string))).(T)
 		chain.value = &mod
 	}
 	return chain
@@ -0,0 +1,174 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Bind() sets every field of the supplied struct pointer that has an `env` tag by driving the chain for its datatype. The following tags are supported:
+//
+//	env:"CONCURRENCY"   the environment variable to read (TrySetBySourcesContext)
+//	default:"8"         the value to use if one was not provided (DefaultTo)
+//	clamp:"1,256"       the minimum and maximum values (Clamp)
+//	required:"true"     the value must be set (Require)
+//	secret:"true"       the value is printed masked (PrintMasked instead of Print)
+//	resolve:"true"      the value may be a Key Vault URL (Resolve)
+//	delimiter:";"       the delimiter for slices (UseDelimiter)
+//	oneof:"a,b"         the value must be one of the options (EnsureOneOf)
+//	prefix:"DB_"        on a nested struct, the prefix for all env keys inside it
+//
+// All problems are returned together rather than panicking.
+func Bind(ctx context.Context, target interface{}) error {
+	return defaultConfig.Bind(ctx, target)
+}
+
+func (c *Config) Bind(ctx context.Context, target interface{}) error {
+	val := reflect.ValueOf(target)
+	if val.Kind() != reflect.Pointer || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("Bind() requires a pointer to a struct but got %T", target)
+	}
+	return c.bindStruct(ctx, val.Elem(), "")
+}
+
+func (c *Config) bindStruct(ctx context.Context, val reflect.Value, prefix string) error {
+	var errs []error
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		fieldVal := val.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		// nested structs
+		key, ok := field.Tag.Lookup("env")
+		if !ok {
+			if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+				if err := c.bindStruct(ctx, fieldVal, prefix+field.Tag.Get("prefix")); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			continue
+		}
+
+		// bind the field based on its datatype
+		var err error
+		key = prefix + key
+		switch {
+		case field.Type == durationType:
+			err = bindField(ctx, c.AsDuration(), key, field.Tag, fieldVal)
+		case field.Type.Kind() == reflect.String:
+			err = bindField(ctx, c.AsString(), key, field.Tag, fieldVal)
+		case field.Type.Kind() == reflect.Int:
+			err = bindField(ctx, c.AsInt(), key, field.Tag, fieldVal)
+		case field.Type.Kind() == reflect.Float64:
+			err = bindField(ctx, c.AsFloat(), key, field.Tag, fieldVal)
+		case field.Type.Kind() == reflect.Bool:
+			err = bindField(ctx, c.AsBool(), key, field.Tag, fieldVal)
+		case field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.String:
+			err = bindField(ctx, c.AsSlice(), key, field.Tag, fieldVal)
+		default:
+			err = fmt.Errorf("%s has an unsupported datatype of %v", key, field.Type)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func bindField[T any](ctx context.Context, chain *Chain[T], key string, tag reflect.StructTag, fieldVal reflect.Value) error {
+	chain.NoPanic()
+
+	// delimiter must be set before any parsing
+	if delimiter, ok := tag.Lookup("delimiter"); ok {
+		chain.UseDelimiter(delimiter)
+	}
+
+	// secrets are never shown in errors
+	if isTrue(tag.Get("secret")) {
+		chain.mask()
+	}
+
+	// set by the registered sources (just env unless UseSources() was called)
+	chain.TrySetBySourcesContext(ctx, key)
+
+	// resolve
+	if isTrue(tag.Get("resolve")) {
+		chain.Resolve(ctx)
+	}
+
+	// ensure one of
+	if oneof, ok := tag.Lookup("oneof"); ok {
+		chain.EnsureOneOf(splitTag(oneof)...)
+	}
+
+	// default
+	if dft, ok := tag.Lookup("default"); ok {
+		val, err := chain.parser.Parse(dft)
+		if err != nil {
+			return fmt.Errorf("%s has a default of %q that could not be parsed - %w", key, dft, err)
+		}
+		chain.DefaultTo(val)
+	}
+
+	// clamp
+	if clamp, ok := tag.Lookup("clamp"); ok {
+		bounds := splitTag(clamp)
+		if len(bounds) != 2 {
+			return fmt.Errorf("%s has a clamp of %q that is not in the format \"min,max\"", key, clamp)
+		}
+		comparer, ok := chain.parser.(Comparer[T])
+		if !ok {
+			return fmt.Errorf("%s has a clamp but Clamp() is not supported for %v", key, fieldVal.Type())
+		}
+		min, err := chain.parser.Parse(bounds[0])
+		if err != nil {
+			return fmt.Errorf("%s has a clamp min of %q that could not be parsed - %w", key, bounds[0], err)
+		}
+		max, err := chain.parser.Parse(bounds[1])
+		if err != nil {
+			return fmt.Errorf("%s has a clamp max of %q that could not be parsed - %w", key, bounds[1], err)
+		}
+		if comparer.Compare(max, min) < 0 {
+			return fmt.Errorf("%s has a clamp of %q where max must be >= min", key, clamp)
+		}
+		chain.Clamp(min, max)
+	}
+
+	// print
+	if isTrue(tag.Get("secret")) {
+		chain.PrintMasked()
+	} else {
+		chain.Print()
+	}
+
+	// require
+	if isTrue(tag.Get("required")) {
+		chain.Require()
+	}
+
+	// set the field
+	fieldVal.Set(reflect.ValueOf(chain.Value()).Convert(fieldVal.Type()))
+
+	return chain.Err()
+}
+
+func splitTag(tag string) []string {
+	parts := strings.Split(tag, ",")
+	for i := range parts {
+		parts[i] = strings.Trim(parts[i], " ")
+	}
+	return parts
+}
+
+func isTrue(tag string) bool {
+	val, err := boolParser{}.Parse(tag)
+	return err == nil && val
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+)
+
+// Cloud describes the endpoints and token audiences for an Azure cloud (see GOCONFIG_CLOUD).
+type Cloud struct {
+	Name            string
+	AppConfigSuffix string
+	AppConfigScope  string
+	KeyVaultSuffix  string
+	KeyVaultScope   string
+	Configuration   cloud.Configuration
+}
+
+var AzurePublic = Cloud{
+	Name:            "AzurePublic",
+	AppConfigSuffix: ".azconfig.io",
+	AppConfigScope:  "https://azconfig.io",
+	KeyVaultSuffix:  ".vault.azure.net",
+	KeyVaultScope:   "https://vault.azure.net",
+	Configuration:   cloud.AzurePublic,
+}
+
+var AzureGovernment = Cloud{
+	Name:            "AzureGovernment",
+	AppConfigSuffix: ".azconfig.azure.us",
+	AppConfigScope:  "https://appconfig.azure.us",
+	KeyVaultSuffix:  ".vault.usgovcloudapi.net",
+	KeyVaultScope:   "https://vault.usgovcloudapi.net",
+	Configuration:   cloud.AzureGovernment,
+}
+
+var AzureChina = Cloud{
+	Name:            "AzureChina",
+	AppConfigSuffix: ".azconfig.azure.cn",
+	AppConfigScope:  "https://appconfig.azure.cn",
+	KeyVaultSuffix:  ".vault.azure.cn",
+	KeyVaultScope:   "https://vault.azure.cn",
+	Configuration:   cloud.AzureChina,
+}
+
+// cloudFor() returns the named cloud. A "custom" cloud has no endpoints so they must all be provided (see validate()).
+func cloudFor(name string) (Cloud, error) {
+	switch strings.ToLower(name) {
+	case "azurepublic", "public":
+		return AzurePublic, nil
+	case "azuregovernment", "government", "usgov":
+		return AzureGovernment, nil
+	case "azurechina", "china":
+		return AzureChina, nil
+	case "custom":
+		return Cloud{Name: "custom"}, nil
+	default:
+		return Cloud{}, fmt.Errorf("GOCONFIG_CLOUD contained an unsupported value: %s", name)
+	}
+}
+
+// validate() ensures that every endpoint was provided, which is only a concern for a "custom" cloud.
+func (c Cloud) validate() error {
+	var missing []string
+	if len(c.AppConfigSuffix) < 1 {
+		missing = append(missing, "GOCONFIG_APPCONFIG_SUFFIX")
+	}
+	if len(c.AppConfigScope) < 1 {
+		missing = append(missing, "GOCONFIG_APPCONFIG_SCOPE")
+	}
+	if len(c.KeyVaultSuffix) < 1 {
+		missing = append(missing, "GOCONFIG_KEYVAULT_SUFFIX")
+	}
+	if len(c.KeyVaultScope) < 1 {
+		missing = append(missing, "GOCONFIG_KEYVAULT_SCOPE")
+	}
+	if len(c.Configuration.ActiveDirectoryAuthorityHost) < 1 {
+		missing = append(missing, "GOCONFIG_AUTHORITY_HOST")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("GOCONFIG_CLOUD of %s requires %s", c.Name, strings.Join(missing, ", "))
+	}
+	return nil
+}
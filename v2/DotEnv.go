@@ -0,0 +1,166 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/joho/godotenv"
+)
+
+var dotenvLock sync.Mutex
+var dotenvFiles = make(map[string]string)
+
+// LoadDotEnv() sets environment variables from .env files that are not already set. If no files are supplied, the
+// comma-delimited list in GOCONFIG_DOTENV is used (or ".env"), with earlier files taking precedence. Each file is layered
+// as "{file}.local", then "{file}.{GOCONFIG_ENVIRONMENT}", then "{file}" and a line of "#include other.env" loads another
+// file (relative to the one including it) at that position. Only the files you supply (or list in GOCONFIG_DOTENV) are required.
+func LoadDotEnv(files ...string) error {
+
+	// determine the files
+	required := len(files) > 0
+	if !required {
+		if list, ok := os.LookupEnv("GOCONFIG_DOTENV"); ok && len(strings.TrimSpace(list)) > 0 {
+			files = splitTag(list)
+			required = true
+		} else {
+			files = []string{".env"}
+		}
+	}
+
+	// read each layer in order of precedence
+	type layer struct {
+		values map[string]string
+		from   map[string]string
+	}
+	var layers []layer
+	read := func(path string, required bool) (*layer, error) {
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) && !required {
+			return nil, nil
+		}
+		values, from, err := readDotEnv(path, nil)
+		if err != nil {
+			return nil, err
+		}
+		return &layer{values, from}, nil
+	}
+	for _, file := range files {
+		local, err := read(file+".local", false)
+		if err != nil {
+			return err
+		}
+		base, err := read(file, required)
+		if err != nil {
+			return err
+		}
+
+		// the environment may be set in the environment, the local file, or the base file
+		environment := os.Getenv("GOCONFIG_ENVIRONMENT")
+		for _, l := range []*layer{local, base} {
+			if l != nil && len(environment) < 1 {
+				environment = l.values["GOCONFIG_ENVIRONMENT"]
+			}
+		}
+		var specific *layer
+		if len(environment) > 0 {
+			if specific, err = read(file+"."+environment, false); err != nil {
+				return err
+			}
+		}
+
+		for _, l := range []*layer{local, specific, base} {
+			if l != nil {
+				layers = append(layers, *l)
+			}
+		}
+	}
+
+	// set anything that is not already in the environment
+	dotenvLock.Lock()
+	defer dotenvLock.Unlock()
+	for _, layer := range layers {
+		for key, val := range layer.values {
+			if _, ok := os.LookupEnv(key); ok {
+				continue
+			}
+			if err := os.Setenv(key, val); err != nil {
+				return err
+			}
+			dotenvFiles[key] = layer.from[key]
+		}
+	}
+
+	return nil
+}
+
+// readDotEnv() parses the file and any files it includes. Values later in the file replace earlier ones and from
+// records the file each value came from.
+func readDotEnv(path string, stack []string) (values map[string]string, from map[string]string, err error) {
+
+	// detect cycles
+	for i, included := range stack {
+		if included == path {
+			return nil, nil, fmt.Errorf("#include cycle detected: %s", strings.Join(append(stack[i:], path), " -> "))
+		}
+	}
+	stack = append(stack, path)
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	values = make(map[string]string)
+	from = make(map[string]string)
+
+	// parse everything between includes with godotenv
+	var segment strings.Builder
+	flush := func() error {
+		parsed, err := godotenv.Unmarshal(segment.String())
+		if err != nil {
+			return fmt.Errorf("%s could not be parsed - %w", path, err)
+		}
+		for key, val := range parsed {
+			values[key] = val
+			from[key] = path
+		}
+		segment.Reset()
+		return nil
+	}
+	// split rather than scan so long lines (ex. an inline base64 PFX) are not limited
+	for _, line := range strings.Split(string(raw), "\n") {
+		include, ok := strings.CutPrefix(strings.TrimSpace(line), "#include ")
+		if !ok {
+			segment.WriteString(line + "\n")
+			continue
+		}
+		if err = flush(); err != nil {
+			return
+		}
+		target := strings.Trim(strings.TrimSpace(include), `"'`)
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(path), target)
+		}
+		included, includedFrom, e := readDotEnv(target, stack)
+		if e != nil {
+			err = fmt.Errorf("%s could not include %s - %w", path, target, e)
+			return
+		}
+		for key, val := range included {
+			values[key] = val
+			from[key] = includedFrom[key]
+		}
+	}
+	err = flush()
+	return
+}
+
+// dotenvFileFor() returns the .env file that set the key or an empty string if it was not set by LoadDotEnv().
+func dotenvFileFor(key string) string {
+	dotenvLock.Lock()
+	defer dotenvLock.Unlock()
+	return dotenvFiles[key]
+}
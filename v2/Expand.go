@@ -0,0 +1,119 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Expand() replaces ${KEY} and ${KEY:-default} in string values set after this call with the values from the registered
+// sources (see UseSources()). The default is used if KEY is not set or empty. Referenced values are expanded too and a
+// cycle is handled like a Resolve() error.
+func (chain *Chain[T]) Expand() *Chain[T] {
+	chain.expand = true
+	return chain
+}
+
+// expandBySources() expands the value of the key using the registered sources.
+func (c *Config) expandBySources(ctx context.Context, key string, value string) (string, error) {
+	list := c.Sources()
+	return expand(key, value, func(name string) (string, bool, error) {
+		for _, source := range list {
+			val, ok, err := source.Lookup(ctx, name)
+			if err != nil || ok {
+				return val, ok, err
+			}
+		}
+		return "", false, nil
+	})
+}
+
+// expandValues() expands every value (as loaded by Apply() or Watch()) referring to the environment first, as it takes
+// precedence unless the value was applied, and then to the other values.
+func (c *Config) expandValues(values map[string]string) (map[string]string, error) {
+	lookup := func(name string) (string, bool, error) {
+		if envOverrides(name) {
+			return os.Getenv(name), true, nil
+		}
+		val, ok := values[name]
+		return val, ok, nil
+	}
+	expanded := make(map[string]string, len(values))
+	for key, value := range values {
+		val, err := expand(key, value, lookup)
+		if err != nil {
+			return nil, err
+		}
+		expanded[key] = val
+	}
+	return expanded, nil
+}
+
+// expand() replaces the references in value using lookup. The key (if any) is where expansion started so a reference
+// back to it is reported as a cycle.
+func expand(key string, value string, lookup func(name string) (string, bool, error)) (string, error) {
+	var stack []string
+	if len(key) > 0 {
+		stack = []string{key}
+	}
+	return expandWith(value, lookup, stack)
+}
+
+func expandWith(value string, lookup func(name string) (string, bool, error), stack []string) (string, error) {
+	var result strings.Builder
+	for {
+
+		// find the next reference and its closing brace (defaults may contain references)
+		start := strings.Index(value, "${")
+		if start < 0 {
+			result.WriteString(value)
+			return result.String(), nil
+		}
+		end, depth := -1, 0
+		for i := start + 2; i < len(value) && end < 0; i++ {
+			switch {
+			case strings.HasPrefix(value[i:], "${"):
+				depth++
+				i++
+			case value[i] == '}' && depth > 0:
+				depth--
+			case value[i] == '}':
+				end = i
+			}
+		}
+		if end < 0 {
+			result.WriteString(value)
+			return result.String(), nil
+		}
+		result.WriteString(value[:start])
+		name, dft, hasDefault := strings.Cut(value[start+2:end], ":-")
+		name = strings.TrimSpace(name)
+		value = value[end+1:]
+
+		// detect cycles
+		for i, key := range stack {
+			if key == name {
+				return "", fmt.Errorf("expansion cycle detected: %s", strings.Join(append(stack[i:], name), " -> "))
+			}
+		}
+
+		// lookup the value (or default) and expand it too
+		val, ok, err := lookup(name)
+		if err != nil {
+			return "", err
+		}
+		if !ok || len(val) < 1 {
+			if !hasDefault {
+				continue
+			}
+			val, err = expandWith(dft, lookup, stack)
+		} else {
+			val, err = expandWith(val, lookup, append(append([]string{}, stack...), name))
+		}
+		if err != nil {
+			return "", err
+		}
+		result.WriteString(val)
+	}
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// readFile() reads a mounted file (ex. a Kubernetes or Docker secret) without trailing newlines. A missing file is not an error.
+func readFile(path string) (string, bool, error) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("%s could not be read - %w", path, err)
+	}
+	return strings.TrimRight(string(raw), "\r\n"), true, nil
+}
+
+// DirSource looks up keys as file names in a directory (ex. /run/secrets). The key is tried as provided, lowercase, and
+// lowercase with dashes (ex. "DB_PASSWORD", "db_password", and "db-password").
+type DirSource struct {
+	Path string
+}
+
+func (source DirSource) Lookup(ctx context.Context, key string) (string, bool, error) {
+	path := source.path(key)
+	if len(path) < 1 {
+		return "", false, nil
+	}
+	return readFile(path)
+}
+
+// path() returns the first file that exists for the key or an empty string if there is none.
+func (source DirSource) path(key string) string {
+	if len(key) < 1 || strings.ContainsAny(key, `/\`) || key == "." || key == ".." {
+		return ""
+	}
+	for _, name := range []string{key, strings.ToLower(key), FlagName(key)} {
+		path := filepath.Join(source.Path, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+func (source DirSource) describe(key string) (string, string) {
+	return "file", source.path(key)
+}
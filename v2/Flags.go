@@ -0,0 +1,123 @@
+package config
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FlagSource looks up flags that were supplied on the command line (flags left at their defaults are not found).
+// Unless Exact is set, keys are converted with FlagName() so FlagSource can be registered alongside EnvSource.
+type FlagSource struct {
+	FlagSet *flag.FlagSet
+	Exact   bool
+}
+
+func (source FlagSource) Lookup(ctx context.Context, key string) (string, bool, error) {
+	fs := source.FlagSet
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+	name := key
+	if !source.Exact {
+		name = FlagName(key)
+	}
+	var val string
+	var ok bool
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			val, ok = f.Value.String(), true
+		}
+	})
+	return val, ok, nil
+}
+
+// explicitSource is implemented by sources whose values were deliberately supplied so they are set even if empty (ex. 0).
+type explicitSource interface {
+	explicit()
+}
+
+func (FlagSource) explicit() {}
+
+func (FlagSource) describe(key string) (string, string) {
+	return "flag", ""
+}
+
+// FlagName() converts a key like "DB_CONNSTRING" into a flag name like "db-connstring".
+func FlagName(key string) string {
+	return strings.ReplaceAll(strings.ToLower(key), "_", "-")
+}
+
+// flagValue is registered by RegisterFlags() so the raw string can be parsed by the chain instead of the flag package.
+type flagValue struct {
+	value  string
+	isBool bool
+}
+
+func (f *flagValue) String() string {
+	return f.value
+}
+
+func (f *flagValue) Set(value string) error {
+	f.value = value
+	return nil
+}
+
+func (f *flagValue) IsBoolFlag() bool {
+	return f.isBool
+}
+
+// RegisterFlags() registers a flag named by FlagName() for each key so FlagSource can find it. It must be called before Parse().
+func RegisterFlags(fs *flag.FlagSet, keys ...string) {
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+	for _, key := range keys {
+		registerFlag(fs, key, false, "")
+	}
+}
+
+// RegisterFlagsFor() registers a flag for each `env` tag of the supplied struct pointer (see Bind()). Bool fields
+// are registered so they may be supplied without a value (ex. --verbose).
+func RegisterFlagsFor(fs *flag.FlagSet, target interface{}) error {
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+	typ := reflect.TypeOf(target)
+	if typ == nil || typ.Kind() != reflect.Pointer || typ.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("RegisterFlagsFor() requires a pointer to a struct but got %T", target)
+	}
+	registerFlagsForStruct(fs, typ.Elem(), "")
+	return nil
+}
+
+func registerFlagsForStruct(fs *flag.FlagSet, typ reflect.Type, prefix string) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key, ok := field.Tag.Lookup("env")
+		if !ok {
+			if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+				registerFlagsForStruct(fs, field.Type, prefix+field.Tag.Get("prefix"))
+			}
+			continue
+		}
+		registerFlag(fs, prefix+key, field.Type.Kind() == reflect.Bool, field.Tag.Get("default"))
+	}
+}
+
+func registerFlag(fs *flag.FlagSet, key string, isBool bool, dft string) {
+	name := FlagName(key)
+	if fs.Lookup(name) != nil {
+		return
+	}
+	usage := fmt.Sprintf("overrides %s", key)
+	if len(dft) > 0 {
+		usage += fmt.Sprintf(" (default %s)", dft)
+	}
+	fs.Var(&flagValue{isBool: isBool}, name, usage)
+}
@@ -1,9 +1,20 @@
 package config
 
+import "errors"
+
+// IChain is implemented by every Chain regardless of datatype.
 type IChain interface {
-	afterSetValue()
-	afterSetStringValue()
-	afterSetEmpty()
-	trySetStringValue(string)
-	isEmpty(value interface{}) bool
+	Key() string
+	Err() error
+}
+
+// Validate() returns all errors recorded by the supplied chains (see NoPanic()) or nil if there were none.
+func Validate(chains ...IChain) error {
+	var errs []error
+	for _, chain := range chains {
+		if err := chain.Err(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
 }
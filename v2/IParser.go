@@ -0,0 +1,12 @@
+package config
+
+// Parser converts strings into a datatype so it can be used in a Chain. Implement it to use As() with your own types.
+type Parser[T any] interface {
+	Parse(value string) (T, error)
+	IsEmpty(value T, empty T) bool
+}
+
+// Comparer is optionally implemented by a Parser to support Clamp().
+type Comparer[T any] interface {
+	Compare(a T, b T) int
+}
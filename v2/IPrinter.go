@@ -0,0 +1,29 @@
+package config
+
+// PrintedValue describes a value printed by Print(), PrintMasked(), or PrintLookup().
+type PrintedValue struct {
+	Key     string
+	Type    string
+	Value   string      // for PrintMasked() this is "(set)", a Key Vault URL, or the result of a Masked() method
+	Masked  bool        // true if printed by PrintMasked()
+	IsSet   bool        // false if the value was never set (PrintMasked() shows "(not-set)")
+	Source  string      // where the value came from (ex. "env", "dotenv", "appconfig", "keyvault", "flag", "file", or "default")
+	File    string      // the file the value came from (if any)
+	Version string      // the Key Vault secret version (if any)
+	Ignored *ParseError // a value that was provided but could not be parsed (Print() only)
+	chain   reportable
+}
+
+// reportable is implemented by every Chain so a Report can include rules applied after the value was printed (ex. Require()).
+type reportable interface {
+	isRequired() bool
+	validationRules() []string
+}
+
+// Printer receives everything printed by the chains and Startup(). The default is a TextPrinter to stdout; use
+// SetPrinter(), WithPrinter(), or UsePrinter() on a chain to replace it.
+type Printer interface {
+	PrintSection(title string)
+	PrintValue(value PrintedValue)
+	PrintWarning(message string)
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// Source supplies string values by key. Implement it to feed chains from somewhere other than environment variables.
+type Source interface {
+	Lookup(ctx context.Context, key string) (string, bool, error)
+}
+
+// EnvSource looks up environment variables. If the variable is not set but one with a "_FILE" suffix is (ex. DB_PASSWORD_FILE),
+// the value is read from the file it names.
+type EnvSource struct{}
+
+func (EnvSource) Lookup(ctx context.Context, key string) (string, bool, error) {
+	if val, ok := os.LookupEnv(key); ok {
+		return val, ok, nil
+	}
+	if path, ok := os.LookupEnv(key + "_FILE"); ok && len(path) > 0 {
+		val, ok, err := readFile(path)
+		if err == nil && !ok {
+			err = fmt.Errorf("%s_FILE was set to %s but the file does not exist", key, path)
+		}
+		return val, ok, err
+	}
+	return "", false, nil
+}
+
+// describedSource is implemented by sources that can describe where a value came from for Print() (ex. "dotenv" and the file).
+type describedSource interface {
+	describe(key string) (source string, file string)
+}
+
+func (EnvSource) describe(key string) (string, string) {
+	if _, ok := os.LookupEnv(key); ok {
+		if file := dotenvFileFor(key); len(file) > 0 {
+			return "dotenv", file
+		}
+		return "env", ""
+	}
+	return "file", os.Getenv(key + "_FILE")
+}
+
+// MapSource looks up keys in a map, which is handy for tests or values from Load().
+type MapSource map[string]string
+
+func (MapSource) describe(key string) (string, string) {
+	return "map", ""
+}
+
+func (source MapSource) Lookup(ctx context.Context, key string) (string, bool, error) {
+	val, ok := source[key]
+	return val, ok, nil
+}
+
+// UseSources() replaces the registered sources that are used by TrySetBySources(). They are evaluated in order.
+func UseSources(list ...Source) {
+	defaultConfig.UseSources(list...)
+}
+
+func (c *Config) UseSources(list ...Source) {
+	c.sourcesLock.Lock()
+	defer c.sourcesLock.Unlock()
+	c.sources = append([]Source{}, list...)
+}
+
+// AddSource() appends a source to the registered sources so it is evaluated after those already registered.
+func AddSource(source Source) {
+	defaultConfig.AddSource(source)
+}
+
+func (c *Config) AddSource(source Source) {
+	c.sourcesLock.Lock()
+	defer c.sourcesLock.Unlock()
+	c.sources = append(c.sources, source)
+}
+
+// Sources() returns the registered sources in the order they are evaluated.
+func Sources() []Source {
+	return defaultConfig.Sources()
+}
+
+func (c *Config) Sources() []Source {
+	c.sourcesLock.Lock()
+	defer c.sourcesLock.Unlock()
+	return append([]Source{}, c.sources...)
+}
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+)
+
+// Config owns everything needed to load configuration (the pre-configuration, credential, token cache, HTTP transport,
+// sources, secret cache, and print output) so components in one binary can use different App Config stores and tests
+// do not leak state into each other. The package-level functions use Default().
+type Config struct {
+	settings preconfig
+	cloud    Cloud
+	out      Printer
+
+	credentialLock sync.Mutex
+	credential     azcore.TokenCredential
+	tokenLock      sync.Mutex
+	tokens         map[string]azcore.AccessToken
+	transport      *http.Transport
+	client         *http.Client
+
+	// startup options
+	dotenv        []string
+	skipDotEnv    bool
+	appconfig     string
+	appconfigKeys []string
+
+	sourcesLock sync.Mutex
+	sources     []Source
+
+	secretLock sync.Mutex
+	secrets    map[string]cachedSecret
+	inflight   map[string]*inflightSecret
+
+	watchLock sync.Mutex
+	setEnv    bool
+	loaded    map[string]string
+	applied   map[string]bool
+	callbacks map[string][]func(old string, new string)
+	sentinel  *string
+}
+
+// Option configures a Config (see New()).
+type Option func(c *Config)
+
+// New() creates a Config that reads from the environment in the Azure public cloud and prints to stdout unless options
+// are supplied. Call Startup() on it to load its pre-configuration. The values loaded by its Apply() and Watch() are
+// kept by the Config (see AppConfigSource()) rather than set in the process environment unless WithEnvironment() is used.
+func New(opts ...Option) *Config {
+	c := &Config{
+		cloud:     AzurePublic,
+		tokens:    make(map[string]azcore.AccessToken),
+		transport: createSharedHttpTransport(),
+		secrets:   make(map[string]cachedSecret),
+		inflight:  make(map[string]*inflightSecret),
+		loaded:    make(map[string]string),
+		applied:   make(map[string]bool),
+		callbacks: make(map[string][]func(old string, new string)),
+	}
+	c.sources = []Source{c.AppConfigSource(), EnvSource{}}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+var defaultConfig = New(WithEnvironment())
+
+// Default() returns the Config used by the package-level functions and by chains that do not call UseConfig().
+func Default() *Config {
+	return defaultConfig
+}
+
+// httpClient() returns the client supplied by WithHTTPClient() or one that uses the shared transport.
+func (c *Config) httpClient() *http.Client {
+	if c.client != nil {
+		return c.client
+	}
+	return &http.Client{Transport: c.transport}
+}
+
+// output() returns the printer (see SetPrinter()).
+func (c *Config) output() Printer {
+	if c.out == nil {
+		return TextPrinter{}
+	}
+	return c.out
+}
+
+// appConfigSource supplies the values loaded by Apply() and Watch() for a Config.
+type appConfigSource struct {
+	c *Config
+}
+
+// AppConfigSource() returns a source for the values loaded by Apply() and Watch(). An environment variable takes
+// precedence unless it was set by Apply() or Watch() (see WithEnvironment()). It is registered ahead of EnvSource
+// by New() and is used by TrySetByEnv().
+func (c *Config) AppConfigSource() Source {
+	return appConfigSource{c: c}
+}
+
+func (source appConfigSource) Lookup(ctx context.Context, key string) (string, bool, error) {
+	if envOverrides(key) {
+		return "", false, nil
+	}
+	source.c.watchLock.Lock()
+	defer source.c.watchLock.Unlock()
+	val, ok := source.c.loaded[key]
+	return val, ok, nil
+}
+
+func (appConfigSource) describe(key string) (string, string) {
+	return "appconfig", ""
+}
+
+// envApplied is every environment variable set by Apply() or Watch() on any Config so the values loaded by one Config
+// never hide those loaded by another.
+var (
+	envAppliedLock sync.Mutex
+	envApplied     = make(map[string]bool)
+)
+
+func setAppliedEnv(key string, value string) {
+	envAppliedLock.Lock()
+	defer envAppliedLock.Unlock()
+	os.Setenv(key, value)
+	envApplied[key] = true
+}
+
+func unsetAppliedEnv(key string) {
+	envAppliedLock.Lock()
+	defer envAppliedLock.Unlock()
+	os.Unsetenv(key)
+	delete(envApplied, key)
+}
+
+// envOverrides() returns true if the environment variable is set by something other than Apply() or Watch().
+func envOverrides(key string) bool {
+	envAppliedLock.Lock()
+	defer envAppliedLock.Unlock()
+	_, set := os.LookupEnv(key)
+	return set && !envApplied[key]
+}
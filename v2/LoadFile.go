@@ -0,0 +1,155 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadFile() reads a YAML, JSON, or TOML file and flattens it into colon-separated keys (ex. "sample:CONCURRENCY") so the
+// same filters as Load() can be applied. Like Load(), the key is the last section and filters are applied in order of
+// precedence. If no filters are supplied, every key is used.
+func LoadFile(path string, filters []string) (values map[string]string, err error) {
+	return loadFile(path, filters, false)
+}
+
+// LoadFileFullyQualified() is the same as LoadFile() except the full colon-separated key is used.
+func LoadFileFullyQualified(path string, filters []string) (values map[string]string, err error) {
+	return loadFile(path, filters, true)
+}
+
+func loadFile(path string, filters []string, useFullyQualifiedName bool) (values map[string]string, err error) {
+
+	// read the file
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	// decode based on the extension
+	var doc interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+		err = decoder.Decode(&doc)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(raw, &doc)
+	case ".toml":
+		err = toml.Unmarshal(raw, &doc)
+	default:
+		err = fmt.Errorf("%s is not a supported file type (.json, .yaml, .yml, or .toml)", path)
+	}
+	if err != nil {
+		err = fmt.Errorf("%s could not be loaded - %w", path, err)
+		return
+	}
+
+	// flatten into colon-separated keys
+	flat := make(map[string]string)
+	flatten(flat, "", doc)
+	keys := make([]string, 0, len(flat))
+	for key := range flat {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// set the values in order of precedence (labels are not supported by files so they are ignored)
+	if len(filters) < 1 {
+		filters = []string{"*"}
+	}
+	values = make(map[string]string)
+	for _, filter := range filters {
+		if i := strings.LastIndex(filter, "@"); i > -1 {
+			filter = filter[:i]
+		}
+		for _, key := range keys {
+			if !isKeyMatch(filter, key) {
+				continue
+			}
+			name := key
+			if !useFullyQualifiedName {
+				path := strings.Split(key, ":")
+				name = strings.ToUpper(path[len(path)-1])
+			}
+			if _, ok := values[name]; !ok {
+				values[name] = flat[key]
+			}
+		}
+	}
+
+	return
+}
+
+// isKeyMatch() implements the App Config key filter syntax: an exact key or a prefix followed by "*".
+func isKeyMatch(filter string, key string) bool {
+	filter = strings.TrimSpace(filter)
+	if strings.HasSuffix(filter, "*") {
+		return strings.HasPrefix(key, strings.TrimSuffix(filter, "*"))
+	}
+	return filter == key
+}
+
+// flatten() adds every leaf of the document to values. Lists of scalars are comma-delimited (see AsSlice()) and lists
+// that contain tables use the index as a section (ex. "servers:0:host").
+func flatten(values map[string]string, prefix string, node interface{}) {
+	join := func(key string) string {
+		if len(prefix) < 1 {
+			return key
+		}
+		return prefix + ":" + key
+	}
+	switch typed := node.(type) {
+	case map[string]interface{}:
+		for key, child := range typed {
+			flatten(values, join(key), child)
+		}
+	case map[interface{}]interface{}:
+		for key, child := range typed {
+			flatten(values, join(fmt.Sprint(key)), child)
+		}
+	case []map[string]interface{}:
+		for i, child := range typed {
+			flatten(values, join(strconv.Itoa(i)), child)
+		}
+	case []interface{}:
+		scalars := make([]string, 0, len(typed))
+		for i, child := range typed {
+			switch child.(type) {
+			case map[string]interface{}, map[interface{}]interface{}, []interface{}:
+				flatten(values, join(strconv.Itoa(i)), child)
+			default:
+				scalars = append(scalars, scalar(child))
+			}
+		}
+		if len(scalars) == len(typed) {
+			values[prefix] = strings.Join(scalars, ",")
+		}
+	default:
+		if len(prefix) > 0 {
+			values[prefix] = scalar(node)
+		}
+	}
+}
+
+func scalar(node interface{}) string {
+	switch typed := node.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	case time.Time:
+		return typed.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(typed)
+	}
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+)
+
+// StartupWithOptions() is the same as Startup() except the default instance is configured by the options first, so
+// it can be configured programmatically without changing the environment.
+func StartupWithOptions(ctx context.Context, opts ...Option) error {
+	return defaultConfig.StartupWithOptions(ctx, opts...)
+}
+
+func (c *Config) StartupWithOptions(ctx context.Context, opts ...Option) error {
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c.Startup(ctx)
+}
+
+// WithEnvironment() makes Apply() and Watch() also set the values in the process environment (without replacing
+// variables that were already set), as the default Config does.
+func WithEnvironment() Option {
+	return func(c *Config) {
+		c.setEnv = true
+	}
+}
+
+// WithDotEnv() loads the supplied .env files (see LoadDotEnv()) instead of those in GOCONFIG_DOTENV.
+func WithDotEnv(paths ...string) Option {
+	return func(c *Config) {
+		c.dotenv = append([]string{}, paths...)
+		c.skipDotEnv = false
+	}
+}
+
+// WithoutDotEnv() does not load any .env files.
+func WithoutDotEnv() Option {
+	return func(c *Config) {
+		c.dotenv = nil
+		c.skipDotEnv = true
+	}
+}
+
+// WithAppConfig() uses the App Config store (a name or URL) and key filters instead of GOCONFIG_APPCONFIG and
+// GOCONFIG_APPCONFIG_KEYS.
+func WithAppConfig(url string, keys ...string) Option {
+	return func(c *Config) {
+		c.appconfig = url
+		c.appconfigKeys = append([]string{}, keys...)
+	}
+}
+
+// WithCredential() uses the supplied credential instead of creating one from GOCONFIG_CREDS.
+func WithCredential(cred azcore.TokenCredential) Option {
+	return func(c *Config) {
+		c.credential = cred
+	}
+}
+
+// WithSources() replaces the registered sources (see UseSources()).
+func WithSources(sources ...Source) Option {
+	return func(c *Config) {
+		c.sources = append([]Source{}, sources...)
+	}
+}
+
+// WithHTTPClient() uses the supplied client for all requests to App Config and Key Vault.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Config) {
+		c.client = client
+	}
+}
+
+// WithOutput() sends everything printed by Startup() and the chains to w as text instead of stdout.
+func WithOutput(w io.Writer) Option {
+	return func(c *Config) {
+		c.out = TextPrinter{Writer: w}
+	}
+}
+
+// WithPrinter() sends everything printed by Startup() and the chains to the printer (ex. SlogPrinter or SilentPrinter).
+func WithPrinter(printer Printer) Option {
+	return func(c *Config) {
+		c.out = printer
+	}
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+)
+
+// TextPrinter writes lines like "  KEY = value" to Writer (or stdout if Writer is nil).
+type TextPrinter struct {
+	Writer io.Writer
+}
+
+func (printer TextPrinter) writer() io.Writer {
+	if printer.Writer == nil {
+		return os.Stdout
+	}
+	return printer.Writer
+}
+
+func (printer TextPrinter) PrintSection(title string) {
+	fmt.Fprintf(printer.writer(), "%s:\n", title)
+}
+
+func (printer TextPrinter) PrintValue(value PrintedValue) {
+	line := fmt.Sprintf("  %s = %s", value.Key, value.Value)
+	if value.Ignored != nil {
+		line += fmt.Sprintf(" (ignored %s - %v)", value.Ignored.quotedValue(), value.Ignored.Reason)
+	}
+	if len(value.Version) > 0 {
+		line += fmt.Sprintf(" (version: %s)", value.Version)
+	}
+	if len(value.File) > 0 {
+		line += fmt.Sprintf(" (from %s)", value.File)
+	}
+	fmt.Fprintln(printer.writer(), line)
+}
+
+func (printer TextPrinter) PrintWarning(message string) {
+	fmt.Fprintf(printer.writer(), "  WARNING: %s\n", message)
+}
+
+// SlogPrinter writes a structured record for each value to Logger (or slog.Default() if Logger is nil).
+type SlogPrinter struct {
+	Logger *slog.Logger
+}
+
+func (printer SlogPrinter) logger() *slog.Logger {
+	if printer.Logger == nil {
+		return slog.Default()
+	}
+	return printer.Logger
+}
+
+func (printer SlogPrinter) PrintSection(title string) {
+	printer.logger().Info(title)
+}
+
+func (printer SlogPrinter) PrintValue(value PrintedValue) {
+	attrs := []any{"key", value.Key, "value", value.Value, "type", value.Type, "source", value.Source}
+	if len(value.File) > 0 {
+		attrs = append(attrs, "file", value.File)
+	}
+	if len(value.Version) > 0 {
+		attrs = append(attrs, "version", value.Version)
+	}
+	if value.Ignored != nil {
+		attrs = append(attrs, "ignored", value.Ignored.Value, "reason", value.Ignored.Reason.Error())
+	}
+	printer.logger().Info("configuration", attrs...)
+}
+
+func (printer SlogPrinter) PrintWarning(message string) {
+	printer.logger().Warn(message)
+}
+
+// SilentPrinter prints nothing.
+type SilentPrinter struct{}
+
+func (SilentPrinter) PrintSection(title string) {}
+
+func (SilentPrinter) PrintValue(value PrintedValue) {}
+
+func (SilentPrinter) PrintWarning(message string) {}
+
+// SetPrinter() replaces the printer for the default instance (see WithPrinter()).
+func SetPrinter(printer Printer) {
+	defaultConfig.SetPrinter(printer)
+}
+
+func (c *Config) SetPrinter(printer Printer) {
+	c.out = printer
+}
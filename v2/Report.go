@@ -0,0 +1,147 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// ReportEntry describes the effective configuration for a single key.
+type ReportEntry struct {
+	Section   string   `json:"section,omitempty"`
+	Key       string   `json:"key"`
+	Type      string   `json:"type"`
+	Value     string   `json:"value"`
+	Masked    bool     `json:"masked"`
+	IsSet     bool     `json:"isSet"`
+	Defaulted bool     `json:"defaulted"`
+	Source    string   `json:"source,omitempty"`
+	File      string   `json:"file,omitempty"`
+	Version   string   `json:"version,omitempty"`
+	Ignored   string   `json:"ignored,omitempty"`
+	Required  bool     `json:"required"`
+	Rules     []string `json:"rules,omitempty"`
+}
+
+// Report is a Printer that records every value printed by the chains so the effective configuration can be exported
+// with WriteJSON() or WriteMarkdown(). Everything is also forwarded to the next printer (if any).
+type Report struct {
+	lock    sync.Mutex
+	next    Printer
+	section string
+	order   []string
+	values  map[string]reportValue
+}
+
+type reportValue struct {
+	section string
+	value   PrintedValue
+}
+
+// NewReport() creates a Report that forwards to next, which may be nil to print nothing (ex. NewReport(TextPrinter{})).
+func NewReport(next Printer) *Report {
+	return &Report{next: next, values: make(map[string]reportValue)}
+}
+
+func (report *Report) PrintSection(title string) {
+	report.lock.Lock()
+	report.section = title
+	report.lock.Unlock()
+	if report.next != nil {
+		report.next.PrintSection(title)
+	}
+}
+
+// PrintValue() records the value; if a key is printed more than once, the last value is kept in its original position.
+func (report *Report) PrintValue(value PrintedValue) {
+	report.lock.Lock()
+	if _, ok := report.values[value.Key]; !ok {
+		report.order = append(report.order, value.Key)
+	}
+	report.values[value.Key] = reportValue{section: report.section, value: value}
+	report.lock.Unlock()
+	if report.next != nil {
+		report.next.PrintValue(value)
+	}
+}
+
+func (report *Report) PrintWarning(message string) {
+	if report.next != nil {
+		report.next.PrintWarning(message)
+	}
+}
+
+// Entries() returns the recorded values in the order they were first printed. Require() and the validation rules are
+// read when this is called so they may be applied after the value was printed.
+func (report *Report) Entries() []ReportEntry {
+	report.lock.Lock()
+	defer report.lock.Unlock()
+	entries := make([]ReportEntry, 0, len(report.order))
+	for _, key := range report.order {
+		recorded := report.values[key]
+		value := recorded.value
+		entry := ReportEntry{
+			Section:   recorded.section,
+			Key:       value.Key,
+			Type:      value.Type,
+			Value:     value.Value,
+			Masked:    value.Masked,
+			IsSet:     value.IsSet,
+			Defaulted: value.Source == "default",
+			Source:    value.Source,
+			File:      value.File,
+			Version:   value.Version,
+		}
+		if value.Ignored != nil {
+			entry.Ignored = value.Ignored.Error()
+		}
+		if value.chain != nil {
+			entry.Required = value.chain.isRequired()
+			entry.Rules = value.chain.validationRules()
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// WriteJSON() writes the entries as an indented JSON array.
+func (report *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report.Entries())
+}
+
+// WriteMarkdown() writes the entries as a Markdown table.
+func (report *Report) WriteMarkdown(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("| Key | Type | Value | Source | Defaulted | Required | Rules |\n")
+	sb.WriteString("| --- | --- | --- | --- | --- | --- | --- |\n")
+	for _, entry := range report.Entries() {
+		source := entry.Source
+		if len(entry.File) > 0 {
+			source = fmt.Sprintf("%s (%s)", source, entry.File)
+		}
+		value := entry.Value
+		if len(entry.Version) > 0 {
+			value = fmt.Sprintf("%s (version: %s)", value, entry.Version)
+		}
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s | %t | %t | %s |\n",
+			escapeMarkdown(entry.Key),
+			escapeMarkdown(entry.Type),
+			escapeMarkdown(value),
+			escapeMarkdown(source),
+			entry.Defaulted,
+			entry.Required,
+			escapeMarkdown(strings.Join(entry.Rules, ", ")))
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// escapeMarkdown() keeps a value within its table cell.
+func escapeMarkdown(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	return strings.ReplaceAll(value, "\n", " ")
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+type resolvable interface {
+	IChain
+	config() *Config
+	keyvaultUrl() (string, bool)
+	applyResolved(val string, info *SecretInfo, err error)
+}
+
+// ResolveAll() is the batch alternative to calling Resolve() on each chain. The chains with a Key Vault URL are resolved
+// concurrently (see GOCONFIG_KEYVAULT_CONCURRENCY) and the results are applied the same way as Resolve(). Rather than
+// panicking, every failure is recorded on its chain and all failures are returned together.
+func ResolveAll(ctx context.Context, chains ...IChain) error {
+	return defaultConfig.ResolveAll(ctx, chains...)
+}
+
+func (c *Config) ResolveAll(ctx context.Context, chains ...IChain) error {
+
+	// find the chains that need resolving
+	var pending []resolvable
+	var urls []string
+	for _, chain := range chains {
+		if r, ok := chain.(resolvable); ok {
+			if url, ok := r.keyvaultUrl(); ok {
+				pending = append(pending, r)
+				urls = append(urls, url)
+			}
+		}
+	}
+
+	// resolve without cancelling the others on failure
+	vals := make([]string, len(pending))
+	infos := make([]*SecretInfo, len(pending))
+	errs := make([]error, len(pending))
+	ran := make([]bool, len(pending))
+	err := runConcurrently(ctx, c.settings.GOCONFIG_KEYVAULT_CONCURRENCY, len(pending), func(ctx context.Context, i int) error {
+		ran[i] = true
+		vals[i], infos[i], errs[i] = pending[i].config().resolve(ctx, urls[i])
+		return nil
+	})
+	for i := range pending {
+		if !ran[i] {
+			errs[i] = err // the context was cancelled before the chain could be resolved
+		}
+		if errs[i] != nil {
+			errs[i] = fmt.Errorf("%s could not be resolved - %w", pending[i].Key(), errs[i])
+		}
+	}
+
+	// apply
+	for i, chain := range pending {
+		chain.applyResolved(vals[i], infos[i], errs[i])
+	}
+
+	return errors.Join(errs...)
+}
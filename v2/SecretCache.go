@@ -0,0 +1,78 @@
+package config
+
+import (
+	"context"
+	"time"
+)
+
+type cachedSecret struct {
+	value   string
+	info    *SecretInfo
+	expires time.Time
+}
+
+type inflightSecret struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int
+	value   string
+	info    *SecretInfo
+	err     error
+}
+
+// resolveCached() returns the secret for the url from the cache if it hasn't expired (see GOCONFIG_KEYVAULT_CACHE_TTL).
+// Otherwise, it is fetched from Key Vault; concurrent calls for the same url wait for a single fetch. The fetch is not
+// tied to any one caller's context so each caller can stop waiting on its own; it is cancelled once nobody is waiting.
+func (c *Config) resolveCached(ctx context.Context, url string) (string, *SecretInfo, error) {
+	c.secretLock.Lock()
+
+	// check cache
+	if secret, ok := c.secrets[url]; ok && time.Now().Before(secret.expires) {
+		c.secretLock.Unlock()
+		return secret.value, secret.info, nil
+	}
+
+	// join a fetch that is already in progress or start one
+	call, ok := c.inflight[url]
+	if !ok {
+		fetchCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &inflightSecret{done: make(chan struct{}), cancel: cancel}
+		c.inflight[url] = call
+		go c.fetchShared(fetchCtx, url, call)
+	}
+	call.waiters++
+	c.secretLock.Unlock()
+
+	// wait for the fetch or for this caller to give up
+	select {
+	case <-call.done:
+		return call.value, call.info, call.err
+	case <-ctx.Done():
+		c.secretLock.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			if c.inflight[url] == call {
+				delete(c.inflight, url)
+			}
+			call.cancel()
+		}
+		c.secretLock.Unlock()
+		return "", nil, ctx.Err()
+	}
+}
+
+// fetchShared() fetches the secret for everyone waiting on call, caches it, and releases the waiters.
+func (c *Config) fetchShared(ctx context.Context, url string, call *inflightSecret) {
+	value, info, err := c.fetchSecret(ctx, url)
+	c.secretLock.Lock()
+	call.value, call.info, call.err = value, info, err
+	if c.inflight[url] == call {
+		delete(c.inflight, url)
+	}
+	if err == nil && c.settings.GOCONFIG_KEYVAULT_CACHE_TTL > 0 {
+		c.secrets[url] = cachedSecret{value: value, info: info, expires: time.Now().Add(c.settings.GOCONFIG_KEYVAULT_CACHE_TTL)}
+	}
+	c.secretLock.Unlock()
+	call.cancel()
+	close(call.done)
+}
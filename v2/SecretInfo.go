@@ -0,0 +1,33 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// SecretInfo is the metadata for a secret that was resolved from Key Vault.
+type SecretInfo struct {
+	ID          string
+	Version     string
+	ContentType string
+	Enabled     bool
+	Expires     *time.Time
+	NotBefore   *time.Time
+}
+
+// check() returns an error if the secret is disabled, expired, or not yet valid.
+func (info *SecretInfo) check() error {
+	now := time.Now()
+	switch {
+	case info == nil:
+		return nil
+	case !info.Enabled:
+		return fmt.Errorf("the secret %s is disabled", info.ID)
+	case info.Expires != nil && now.After(*info.Expires):
+		return fmt.Errorf("the secret %s expired at %s", info.ID, info.Expires.Format(time.RFC3339))
+	case info.NotBefore != nil && now.Before(*info.NotBefore):
+		return fmt.Errorf("the secret %s is not valid until %s", info.ID, info.NotBefore.Format(time.RFC3339))
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"context"
+	"sync"
+)
+
+// Session collects chains (see UseSession()) so every configuration problem can be reported at once.
+type Session struct {
+	lock   sync.Mutex
+	chains []IChain
+}
+
+func NewSession() *Session {
+	return &Session{}
+}
+
+func (session *Session) register(chain IChain) {
+	session.lock.Lock()
+	defer session.lock.Unlock()
+	session.chains = append(session.chains, chain)
+}
+
+// Err() returns every error recorded by the registered chains or nil if there were none.
+func (session *Session) Err() error {
+	session.lock.Lock()
+	defer session.lock.Unlock()
+	return Validate(session.chains...)
+}
+
+// ResolveAll() resolves every registered chain with a Key Vault URL concurrently (see ResolveAll()).
+func (session *Session) ResolveAll(ctx context.Context) error {
+	session.lock.Lock()
+	chains := append([]IChain(nil), session.chains...)
+	session.lock.Unlock()
+	return ResolveAll(ctx, chains...)
+}
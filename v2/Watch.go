@@ -0,0 +1,146 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// OnChange() registers a callback that is raised by Watch() when the value applied to the environment for key changes.
+func OnChange(key string, f func(old string, new string)) {
+	defaultConfig.OnChange(key, f)
+}
+
+func (c *Config) OnChange(key string, f func(old string, new string)) {
+	c.watchLock.Lock()
+	defer c.watchLock.Unlock()
+	c.callbacks[key] = append(c.callbacks[key], f)
+}
+
+// Watch() periodically reloads GOCONFIG_APPCONFIG_KEYS from App Config until the context is cancelled, updates the
+// values that were loaded by Apply() (and the environment, see WithEnvironment()), and raises the OnChange() callbacks.
+// If GOCONFIG_APPCONFIG_SENTINEL is set, only that key is fetched on each interval and everything is reloaded only when
+// its value changes. Errors are sent to onError (if provided) and the watch continues. The interval must be positive.
+func Watch(ctx context.Context, interval time.Duration, onError func(error)) {
+	defaultConfig.Watch(ctx, interval, onError)
+}
+
+func (c *Config) Watch(ctx context.Context, interval time.Duration, onError func(error)) {
+	if interval <= 0 {
+		panic(fmt.Errorf("Watch() requires a positive interval but got %v", interval))
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := c.refresh(ctx); err != nil && onError != nil {
+					onError(err)
+				}
+			}
+		}
+	}()
+}
+
+func (c *Config) refresh(ctx context.Context) error {
+
+	// check the sentinel to see if a reload is needed
+	if len(c.settings.GOCONFIG_APPCONFIG_SENTINEL) > 0 {
+		changed, err := c.checkSentinel(ctx)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			return nil
+		}
+	}
+
+	// reload
+	values, err := c.loadWith(ctx, c.settings.GOCONFIG_APPCONFIG_KEYS, false, c.settings.GOCONFIG_EXPAND)
+	if err != nil {
+		return err
+	}
+	c.apply(values)
+
+	return nil
+}
+
+// checkSentinel() fetches GOCONFIG_APPCONFIG_SENTINEL and records its value. It returns true if the value is different
+// from the one recorded by Apply() or the last check (or if there wasn't one).
+func (c *Config) checkSentinel(ctx context.Context) (bool, error) {
+	values, err := c.load(ctx, []string{c.settings.GOCONFIG_APPCONFIG_SENTINEL}, true)
+	if err != nil {
+		return false, err
+	}
+	val := values[c.settings.GOCONFIG_APPCONFIG_SENTINEL]
+	c.watchLock.Lock()
+	defer c.watchLock.Unlock()
+	changed := c.sentinel == nil || *c.sentinel != val
+	c.sentinel = &val
+	return changed, nil
+}
+
+// wasApplied() returns true if the environment variable was set by Apply() or Watch().
+func (c *Config) wasApplied(key string) bool {
+	c.watchLock.Lock()
+	defer c.watchLock.Unlock()
+	return c.applied[key]
+}
+
+// apply() keeps the values (updating the environment if appropriate) and raises the callbacks for any that changed
+// since the last load.
+func (c *Config) apply(values map[string]string) {
+	type change struct {
+		key string
+		old string
+		new string
+	}
+	var changes []change
+
+	// diff under lock
+	c.watchLock.Lock()
+	for key, value := range values {
+		old, ok := c.loaded[key]
+		c.loaded[key] = value
+		if ok && old == value {
+			continue
+		}
+		if envOverrides(key) {
+			continue // the environment takes precedence
+		}
+		if _, set := os.LookupEnv(key); c.setEnv && (!set || c.applied[key]) {
+			setAppliedEnv(key, value)
+			c.applied[key] = true
+		}
+		changes = append(changes, change{key: key, old: old, new: value})
+	}
+	for key, old := range c.loaded {
+		if _, ok := values[key]; !ok {
+			delete(c.loaded, key)
+			if c.applied[key] {
+				unsetAppliedEnv(key)
+				delete(c.applied, key)
+			}
+			if !envOverrides(key) {
+				changes = append(changes, change{key: key, old: old, new: ""})
+			}
+		}
+	}
+	raise := make(map[string][]func(old string, new string), len(c.callbacks))
+	for key, list := range c.callbacks {
+		raise[key] = list
+	}
+	c.watchLock.Unlock()
+
+	// raise the callbacks outside of the lock so they may call OnChange()
+	for _, ch := range changes {
+		for _, f := range raise[ch.key] {
+			f(ch.old, ch.new)
+		}
+	}
+
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -15,7 +18,6 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/go-autorest/autorest"
-	"github.com/joho/godotenv"
 )
 
 // Allows a single line pattern that would emulate (condition ? true : false).
@@ -27,18 +29,23 @@ func IfThenElse(condition bool, a interface{}, b interface{}) interface{} {
 }
 
 type preconfig struct {
-	GOCONFIG_CREDS          []string
-	GOCONFIG_APPCONFIG      string
-	GOCONFIG_APPCONFIG_KEYS []string
+	GOCONFIG_DOTENV                []string
+	GOCONFIG_ENVIRONMENT           string
+	GOCONFIG_CLOUD                 string
+	GOCONFIG_CREDS                 []string
+	GOCONFIG_APPCONFIG             string
+	GOCONFIG_APPCONFIG_KEYS        []string
+	GOCONFIG_APPCONFIG_LABELS      []string
+	GOCONFIG_APPCONFIG_NULL_LABEL  bool
+	GOCONFIG_APPCONFIG_SENTINEL    string
+	GOCONFIG_APPCONFIG_CONCURRENCY int
+	GOCONFIG_EXPAND                bool
+	GOCONFIG_RESOLVE_ON_LOAD       bool
+	GOCONFIG_KEYVAULT_CONCURRENCY  int
+	GOCONFIG_KEYVAULT_CACHE_TTL    time.Duration
+	GOCONFIG_KEYVAULT_EXPIRED      string
 }
 
-var config preconfig
-var credentialLock sync.Mutex
-var credential *azidentity.ChainedTokenCredential
-var tokenLock sync.Mutex
-var tokens map[string]azcore.AccessToken = make(map[string]azcore.AccessToken)
-var sharedHttpTransport *http.Transport
-
 func createSharedHttpTransport() *http.Transport {
 	defaultTransport := http.DefaultTransport.(*http.Transport)
 	return &http.Transport{
@@ -56,25 +63,30 @@ func createSharedHttpTransport() *http.Transport {
 }
 
 func GetCredential() (azcore.TokenCredential, error) {
-	credentialLock.Lock()
-	defer credentialLock.Unlock()
+	return defaultConfig.GetCredential()
+}
+
+func (c *Config) GetCredential() (azcore.TokenCredential, error) {
+	c.credentialLock.Lock()
+	defer c.credentialLock.Unlock()
 
 	// check cache
-	if credential != nil {
-		return credential, nil
+	if c.credential != nil {
+		return c.credential, nil
 	}
 
-	// create a list of creds
+	// create a list of creds for the active cloud
+	opts := azcore.ClientOptions{Cloud: c.cloud.Configuration}
 	var creds []azcore.TokenCredential
-	for _, cred := range config.GOCONFIG_CREDS {
+	for _, cred := range c.settings.GOCONFIG_CREDS {
 		switch strings.ToLower(cred) {
 		case "env":
-			env, err := azidentity.NewEnvironmentCredential(nil)
+			env, err := azidentity.NewEnvironmentCredential(&azidentity.EnvironmentCredentialOptions{ClientOptions: opts})
 			if err == nil {
 				creds = append(creds, env)
 			}
 		case "mi":
-			mi, err := azidentity.NewManagedIdentityCredential(nil)
+			mi, err := azidentity.NewManagedIdentityCredential(&azidentity.ManagedIdentityCredentialOptions{ClientOptions: opts})
 			if err == nil {
 				creds = append(creds, mi)
 			}
@@ -84,7 +96,7 @@ func GetCredential() (azcore.TokenCredential, error) {
 				creds = append(creds, cli)
 			}
 		case "default":
-			dft, err := azidentity.NewDefaultAzureCredential(nil)
+			dft, err := azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{ClientOptions: opts})
 			if err == nil {
 				creds = append(creds, dft)
 			}
@@ -100,22 +112,26 @@ func GetCredential() (azcore.TokenCredential, error) {
 		return nil, err
 	}
 
-	credential = chain
+	c.credential = chain
 	return chain, nil
 }
 
 func GetAccessToken(ctx context.Context, scope string) (string, error) {
-	tokenLock.Lock()
-	defer tokenLock.Unlock()
+	return defaultConfig.GetAccessToken(ctx, scope)
+}
+
+func (c *Config) GetAccessToken(ctx context.Context, scope string) (string, error) {
+	c.tokenLock.Lock()
+	defer c.tokenLock.Unlock()
 
 	// check cache
-	token, ok := tokens[scope]
+	token, ok := c.tokens[scope]
 	if ok && time.Until(token.ExpiresOn).Minutes() >= 5 {
 		return token.Token, nil
 	}
 
 	// get credential
-	cred, err := GetCredential()
+	cred, err := c.GetCredential()
 	if err != nil {
 		return "", err
 	}
@@ -127,15 +143,20 @@ func GetAccessToken(ctx context.Context, scope string) (string, error) {
 		return "", err
 	}
 
-	tokens[scope] = accessToken
+	c.tokens[scope] = accessToken
 	return accessToken.Token, nil
 }
 
-func tryExtractUrlForKeyvaultFromAppConfigEntry(value string) string {
+func (c *Config) isKeyvaultUrl(value string) bool {
+	lower := strings.ToLower(value)
+	return strings.HasPrefix(lower, "https://") && strings.Contains(lower, strings.ToLower(c.cloud.KeyVaultSuffix)+"/")
+}
+
+func (c *Config) tryExtractUrlForKeyvaultFromAppConfigEntry(value string) string {
 
 	// make sure this is a keyvault entry
 	lower := strings.ToLower(value)
-	if !strings.HasPrefix(lower, "{") || !strings.HasSuffix(lower, "}") || !strings.Contains(lower, ".vault.azure.net/") {
+	if !strings.HasPrefix(lower, "{") || !strings.HasSuffix(lower, "}") || !strings.Contains(lower, strings.ToLower(c.cloud.KeyVaultSuffix)+"/") {
 		return value
 	}
 
@@ -155,44 +176,117 @@ func tryExtractUrlForKeyvaultFromAppConfigEntry(value string) string {
 
 }
 
-func load(ctx context.Context, filters []string, useFullyQualifiedName bool) (values map[string]string, err error) {
-	values = make(map[string]string)
+// the label App Config uses for key/values that do not have a label
+const nullLabel = "\x00"
 
-	// make sure there is something to load
-	if len(filters) < 1 {
-		return
+type appConfigQuery struct {
+	filter string
+	label  *string
+}
+
+// queriesFor() expands the filters into one query per label in order of precedence. A filter may specify its own
+// label (ex. "sample:*@prod") instead of using GOCONFIG_APPCONFIG_LABELS. If any labels are used and
+// GOCONFIG_APPCONFIG_NULL_LABEL is true, the null label is queried last for each filter.
+func (c *Config) queriesFor(filters []string) []appConfigQuery {
+	var queries []appConfigQuery
+	for _, filter := range filters {
+		labels := c.settings.GOCONFIG_APPCONFIG_LABELS
+		if i := strings.LastIndex(filter, "@"); i > -1 {
+			labels = []string{filter[i+1:]}
+			filter = filter[:i]
+		}
+		if len(labels) < 1 {
+			queries = append(queries, appConfigQuery{filter: filter})
+			continue
+		}
+		for i := range labels {
+			queries = append(queries, appConfigQuery{filter: filter, label: &labels[i]})
+		}
+		if c.settings.GOCONFIG_APPCONFIG_NULL_LABEL {
+			label := nullLabel
+			queries = append(queries, appConfigQuery{filter: filter, label: &label})
+		}
 	}
+	return queries
+}
 
-	// make sure APPCONFIG is supplied so the load can happen
-	if len(config.GOCONFIG_APPCONFIG) < 1 {
-		err = fmt.Errorf("GOCONFIG_APPCONFIG was REQUIRED but not set")
-		return
+// the number of requests made in parallel if GOCONFIG_APPCONFIG_CONCURRENCY or GOCONFIG_KEYVAULT_CONCURRENCY is not set
+const defaultConcurrency = 4
+
+type appConfigItem struct {
+	ContentType string `json:"content_type"`
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+}
+
+// the number of times a request to appconfig will be retried when throttled (HTTP 429)
+const maxThrottledRetries = 5
+
+// retryAfter() returns how long to wait before retrying a throttled request based on the response headers.
+func retryAfter(resp *http.Response) time.Duration {
+	if ms, err := strconv.Atoi(resp.Header.Get("retry-after-ms")); err == nil {
+		return time.Duration(ms) * time.Millisecond
+	}
+	if sec, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return time.Duration(sec) * time.Second
 	}
+	return time.Second
+}
 
-	// request each filter
-	// TODO: improve performance by fetching these concurrently
-	for _, filter := range filters {
+// loadPage() fetches a single page of key/values from appconfig and returns the link to the next page (if any).
+// resolveNextLink() resolves the nextLink against GOCONFIG_APPCONFIG and rejects a link to any other scheme or host
+// so the token is only ever sent to the store.
+func (c *Config) resolveNextLink(link string) (string, error) {
+	base, err := url.Parse(c.settings.GOCONFIG_APPCONFIG)
+	if err != nil {
+		return "", fmt.Errorf("GOCONFIG_APPCONFIG could not be parsed - %w", err)
+	}
+	ref, err := url.Parse(link)
+	if err != nil {
+		return "", fmt.Errorf("the nextLink %q could not be parsed - %w", link, err)
+	}
+	next := base.ResolveReference(ref)
+	if next.Scheme != base.Scheme || next.Host != base.Host {
+		return "", fmt.Errorf("the nextLink %q is not for the App Config store %s", link, base.Host)
+	}
+	return next.String(), nil
+}
+
+func (c *Config) loadPage(ctx context.Context, query appConfigQuery, link string) (items []appConfigItem, next string, err error) {
+	for attempt := 0; ; attempt++ {
 		// create the client
 		client := &autorest.Client{
-			Sender: &http.Client{Transport: sharedHttpTransport},
+			Sender: c.httpClient(),
 		}
 
 		// get the token
 		var token string
-		token, err = GetAccessToken(ctx, "https://azconfig.io")
+		token, err = c.GetAccessToken(ctx, c.cloud.AppConfigScope)
 		if err != nil {
 			return
 		}
 
-		// setup the request
-		q := map[string]interface{}{"key": filter}
+		// setup the request; the first page is built from the filter, subsequent pages use the nextLink
+		decorators := []autorest.PrepareDecorator{autorest.AsGet()}
+		if len(link) > 0 {
+			var resolved string
+			if resolved, err = c.resolveNextLink(link); err != nil {
+				return
+			}
+			decorators = append(decorators, autorest.WithBaseURL(resolved))
+		} else {
+			q := map[string]interface{}{"key": query.filter}
+			if query.label != nil {
+				q["label"] = *query.label
+			}
+			decorators = append(decorators,
+				autorest.WithBaseURL(c.settings.GOCONFIG_APPCONFIG),
+				autorest.WithPath("/kv"),
+				autorest.WithQueryParameters(q))
+		}
+		decorators = append(decorators, autorest.WithBearerAuthorization(token))
 		var req *http.Request
-		req, err = autorest.Prepare(&http.Request{},
-			autorest.AsGet(),
-			autorest.WithBaseURL(config.GOCONFIG_APPCONFIG),
-			autorest.WithPath("/kv"),
-			autorest.WithQueryParameters(q),
-			autorest.WithBearerAuthorization(token))
+		req, err = autorest.Prepare(&http.Request{}, decorators...)
 		if err != nil {
 			return
 		}
@@ -203,21 +297,30 @@ func load(ctx context.Context, filters []string, useFullyQualifiedName bool) (va
 		if err != nil {
 			return
 		}
+
+		// wait and retry if throttled
+		if resp.StatusCode == http.StatusTooManyRequests && attempt < maxThrottledRetries {
+			resp.Body.Close()
+			select {
+			case <-ctx.Done():
+				err = ctx.Err()
+				return
+			case <-time.After(retryAfter(resp)):
+				continue
+			}
+		}
 		defer resp.Body.Close()
 
 		// ensure it is something in the HTTP 200 range
 		if resp.StatusCode < 200 || resp.StatusCode > 299 {
-			err = fmt.Errorf("GET from appconfig (filter: %s) resulted in HTTP %d - %s", filter, resp.StatusCode, resp.Status)
+			err = fmt.Errorf("GET from appconfig (filter: %s) resulted in HTTP %d - %s", query.filter, resp.StatusCode, resp.Status)
 			return
 		}
 
 		// define the json structure of the appconfig response
 		result := struct {
-			Items []struct {
-				ContentType string `json:"content_type"`
-				Key         string `json:"key"`
-				Value       string `json:"value"`
-			} `json:"items"`
+			Items    []appConfigItem `json:"items"`
+			NextLink string          `json:"@nextLink"`
 		}{}
 
 		// deserialize to json
@@ -227,71 +330,243 @@ func load(ctx context.Context, filters []string, useFullyQualifiedName bool) (va
 			return
 		}
 
-		// set the values
-		for _, item := range result.Items {
+		return result.Items, result.NextLink, nil
+	}
+}
+
+func (c *Config) load(ctx context.Context, filters []string, useFullyQualifiedName bool) (values map[string]string, err error) {
+	return c.loadWith(ctx, filters, useFullyQualifiedName, false)
+}
+
+// loadWith() is load() but, if expand is true, references between the values are expanded before any Key Vault
+// references are resolved so secrets are never expanded.
+func (c *Config) loadWith(ctx context.Context, filters []string, useFullyQualifiedName bool, expand bool) (values map[string]string, err error) {
+	values = make(map[string]string)
+
+	// make sure there is something to load
+	if len(filters) < 1 {
+		return
+	}
+
+	// make sure APPCONFIG is supplied so the load can happen
+	if len(c.settings.GOCONFIG_APPCONFIG) < 1 {
+		err = fmt.Errorf("GOCONFIG_APPCONFIG was REQUIRED but not set")
+		return
+	}
+
+	// request each filter (for each label) concurrently
+	queries := c.queriesFor(filters)
+	results := make([][]appConfigItem, len(queries))
+	err = runConcurrently(ctx, c.settings.GOCONFIG_APPCONFIG_CONCURRENCY, len(queries), func(ctx context.Context, i int) (err error) {
+		results[i], err = c.loadQuery(ctx, queries[i])
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	// set the values in order of precedence
+	refs := make(map[string]bool)
+	for _, items := range results {
+		for _, item := range items {
 			key := item.Key
 			if !useFullyQualifiedName {
 				path := strings.Split(item.Key, ":")
 				key = strings.ToUpper(path[len(path)-1])
 			}
 			if _, ok := values[key]; !ok {
-				val := tryExtractUrlForKeyvaultFromAppConfigEntry(item.Value)
+				val := c.tryExtractUrlForKeyvaultFromAppConfigEntry(item.Value)
 				values[key] = val
+				refs[key] = val != item.Value
 			}
 		}
+	}
 
+	// expand references between values
+	if expand {
+		if values, err = c.expandValues(values); err != nil {
+			return
+		}
+	}
+
+	// resolve the Key Vault references if appropriate
+	if c.settings.GOCONFIG_RESOLVE_ON_LOAD {
+		err = c.resolveReferences(ctx, values, refs)
+	}
+
+	return
+}
+
+// resolveReferences() replaces the Key Vault references in values with their secrets.
+func (c *Config) resolveReferences(ctx context.Context, values map[string]string, refs map[string]bool) error {
+	var keys []string
+	for key, ref := range refs {
+		if ref {
+			keys = append(keys, key)
+		}
+	}
+	secrets := make([]string, len(keys))
+	err := runConcurrently(ctx, c.settings.GOCONFIG_KEYVAULT_CONCURRENCY, len(keys), func(ctx context.Context, i int) (err error) {
+		secrets[i], _, err = c.resolve(ctx, values[keys[i]])
+		if err != nil {
+			err = fmt.Errorf("%s could not be resolved - %w", keys[i], err)
+		}
+		return
+	})
+	if err != nil {
+		return err
+	}
+	for i, key := range keys {
+		values[key] = secrets[i]
+	}
+	return nil
+}
+
+// runConcurrently() calls f for each index from 0 to count with no more than concurrency calls in flight (or
+// defaultConcurrency if concurrency is not set). The first error cancels the context for all outstanding calls and is returned.
+func runConcurrently(ctx context.Context, concurrency int, count int, f func(ctx context.Context, i int) error) (err error) {
+	errs := make([]error, count)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if concurrency < 1 {
+		concurrency = defaultConcurrency
 	}
+	limit := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			select {
+			case limit <- struct{}{}:
+				defer func() { <-limit }()
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			errs[i] = f(ctx, i)
+			if errs[i] != nil {
+				cancel()
+			}
+		}(i)
+	}
+	wg.Wait()
 
+	// report the error that caused the cancellation rather than the cancellation itself
+	for _, e := range errs {
+		if e != nil && (err == nil || errors.Is(err, context.Canceled)) {
+			err = e
+		}
+	}
 	return
 }
 
+// loadQuery() follows the nextLink until all pages for the query have been read.
+func (c *Config) loadQuery(ctx context.Context, query appConfigQuery) (items []appConfigItem, err error) {
+	link := ""
+	for {
+		var page []appConfigItem
+		page, link, err = c.loadPage(ctx, query, link)
+		if err != nil {
+			return
+		}
+		items = append(items, page...)
+		if len(link) < 1 {
+			return
+		}
+	}
+}
+
 func Load(ctx context.Context, filters []string) (values map[string]string, err error) {
-	return load(ctx, filters, false)
+	return defaultConfig.Load(ctx, filters)
+}
+
+func (c *Config) Load(ctx context.Context, filters []string) (values map[string]string, err error) {
+	return c.load(ctx, filters, false)
 }
 
 func LoadFullyQualified(ctx context.Context, filters []string) (values map[string]string, err error) {
-	return load(ctx, filters, true)
+	return defaultConfig.LoadFullyQualified(ctx, filters)
+}
+
+func (c *Config) LoadFullyQualified(ctx context.Context, filters []string) (values map[string]string, err error) {
+	return c.load(ctx, filters, true)
 }
 
 func Apply(ctx context.Context, filters []string) (err error) {
+	return defaultConfig.Apply(ctx, filters)
+}
+
+func (c *Config) Apply(ctx context.Context, filters []string) (err error) {
 	// make sure there is something to apply
 	if len(filters) < 1 {
 		return
 	}
 
+	// record the sentinel before loading so a change made after this point is reloaded by Watch()
+	if len(c.settings.GOCONFIG_APPCONFIG_SENTINEL) > 0 {
+		if _, err = c.checkSentinel(ctx); err != nil {
+			return
+		}
+	}
+
 	// load the values
-	values, err := load(ctx, filters, false)
+	values, err := c.loadWith(ctx, filters, false, c.settings.GOCONFIG_EXPAND)
 	if err != nil {
 		return
 	}
 
-	// apply to env (if not already set)
+	// keep the values and, if appropriate, apply to env (if not already set)
+	c.watchLock.Lock()
+	defer c.watchLock.Unlock()
 	for key, value := range values {
-		if _, ok := os.LookupEnv(key); !ok {
-			os.Setenv(key, value)
+		if _, ok := os.LookupEnv(key); !ok && c.setEnv {
+			setAppliedEnv(key, value)
+			c.applied[key] = true
 		}
+		c.loaded[key] = value
 	}
 
 	return
 }
 
-func resolve(ctx context.Context, url string) (val string, err error) {
+// resolve() returns the secret (and its metadata) if url is a Key Vault URL or url itself if it is not.
+func (c *Config) resolve(ctx context.Context, url string) (val string, info *SecretInfo, err error) {
 	val = url
 
 	// make sure this is a valid URL
 	url = strings.ToLower(url)
-	if !strings.HasPrefix(url, "https://") || !strings.Contains(url, ".vault.azure.net/") {
+	if !c.isKeyvaultUrl(url) {
 		return
 	}
 
+	// get the secret
+	val, info, err = c.resolveCached(ctx, url)
+	if err != nil {
+		return
+	}
+
+	// ensure the secret can be used
+	if err = info.check(); err != nil {
+		if c.settings.GOCONFIG_KEYVAULT_EXPIRED != "fail" {
+			c.output().PrintWarning(err.Error())
+			err = nil
+		}
+	}
+
+	return
+}
+
+func (c *Config) fetchSecret(ctx context.Context, url string) (val string, info *SecretInfo, err error) {
+
 	// create the client
 	client := &autorest.Client{
-		Sender: &http.Client{Transport: sharedHttpTransport},
+		Sender: c.httpClient(),
 	}
 
 	// get the token
 	var token string
-	token, err = GetAccessToken(ctx, "https://vault.azure.net")
+	token, err = c.GetAccessToken(ctx, c.cloud.KeyVaultScope)
 	if err != nil {
 		return
 	}
@@ -324,7 +599,14 @@ func resolve(ctx context.Context, url string) (val string, err error) {
 
 	// define the json structure of the keyvault response
 	result := struct {
-		Value string `json:"value"`
+		Value       string `json:"value"`
+		ID          string `json:"id"`
+		ContentType string `json:"contentType"`
+		Attributes  struct {
+			Enabled   *bool  `json:"enabled"`
+			Expires   *int64 `json:"exp"`
+			NotBefore *int64 `json:"nbf"`
+		} `json:"attributes"`
 	}{}
 
 	// deserialize to json
@@ -334,8 +616,22 @@ func resolve(ctx context.Context, url string) (val string, err error) {
 		return
 	}
 
-	// extract the value
+	// extract the value and metadata
 	val = result.Value
+	info = &SecretInfo{
+		ID:          result.ID,
+		Version:     result.ID[strings.LastIndex(result.ID, "/")+1:],
+		ContentType: result.ContentType,
+		Enabled:     result.Attributes.Enabled == nil || *result.Attributes.Enabled,
+	}
+	if result.Attributes.Expires != nil {
+		expires := time.Unix(*result.Attributes.Expires, 0).UTC()
+		info.Expires = &expires
+	}
+	if result.Attributes.NotBefore != nil {
+		notBefore := time.Unix(*result.Attributes.NotBefore, 0).UTC()
+		info.NotBefore = &notBefore
+	}
 
 	return
 }
@@ -362,27 +658,38 @@ func areSlicesEqual(a []string, b []string) bool {
 }
 
 func Startup(ctx context.Context) (err error) {
+	return defaultConfig.Startup(ctx)
+}
 
-	// create a shared http transport
-	if sharedHttpTransport == nil {
-		sharedHttpTransport = createSharedHttpTransport()
-	}
+func (c *Config) Startup(ctx context.Context) (err error) {
 
 	// load from dotenv
-	//  NOTE: ignore *os.PathError (the file is optional)
-	err = godotenv.Load()
-	if err != nil {
-		if _, ok := err.(*os.PathError); !ok {
-			return err
-		} else {
-			err = nil
+	if !c.skipDotEnv {
+		if err = LoadDotEnv(c.dotenv...); err != nil {
+			return
 		}
 	}
 
 	// do pre-configuration
-	fmt.Println("PRE-CONFIGURATION:")
-	config.GOCONFIG_CREDS = AsSlice().TrySetByEnv("GOCONFIG_CREDS").DefaultTo([]string{"default"}).Print().Value()
-	config.GOCONFIG_APPCONFIG = AsString().TrySetByEnv("GOCONFIG_APPCONFIG").Transform(func(chain *StringChain) {
+	c.output().PrintSection("PRE-CONFIGURATION")
+	if !c.skipDotEnv {
+		c.settings.GOCONFIG_DOTENV = c.AsSlice().TrySetValue(c.dotenv).TrySetByEnv("GOCONFIG_DOTENV").DefaultTo([]string{".env"}).Print().Value()
+	}
+	c.settings.GOCONFIG_ENVIRONMENT = c.AsString().TrySetByEnv("GOCONFIG_ENVIRONMENT").Print().Value()
+	c.settings.GOCONFIG_CLOUD = c.AsString().TrySetByEnv("GOCONFIG_CLOUD").DefaultTo("AzurePublic").Print().Value()
+	if c.cloud, err = cloudFor(c.settings.GOCONFIG_CLOUD); err != nil {
+		return
+	}
+	c.cloud.AppConfigSuffix = c.AsString().TrySetByEnv("GOCONFIG_APPCONFIG_SUFFIX").DefaultTo(c.cloud.AppConfigSuffix).Print().Value()
+	c.cloud.AppConfigScope = c.AsString().TrySetByEnv("GOCONFIG_APPCONFIG_SCOPE").DefaultTo(c.cloud.AppConfigScope).Print().Value()
+	c.cloud.KeyVaultSuffix = c.AsString().TrySetByEnv("GOCONFIG_KEYVAULT_SUFFIX").DefaultTo(c.cloud.KeyVaultSuffix).Print().Value()
+	c.cloud.KeyVaultScope = c.AsString().TrySetByEnv("GOCONFIG_KEYVAULT_SCOPE").DefaultTo(c.cloud.KeyVaultScope).Print().Value()
+	c.cloud.Configuration.ActiveDirectoryAuthorityHost = c.AsString().TrySetByEnv("GOCONFIG_AUTHORITY_HOST").DefaultTo(c.cloud.Configuration.ActiveDirectoryAuthorityHost).Print().Value()
+	if err = c.cloud.validate(); err != nil {
+		return
+	}
+	c.settings.GOCONFIG_CREDS = c.AsSlice().TrySetByEnv("GOCONFIG_CREDS").DefaultTo([]string{"default"}).Print().Value()
+	c.settings.GOCONFIG_APPCONFIG = c.AsString().TrySetValue(c.appconfig).TrySetByEnv("GOCONFIG_APPCONFIG").Transform(func(chain *StringChain) {
 		if chain.IsValueSet() {
 			val := strings.ToLower(chain.Value())
 			if !strings.HasPrefix(val, "https://") {
@@ -391,17 +698,26 @@ func Startup(ctx context.Context) (err error) {
 			if strings.HasSuffix(val, "/") {
 				val = strings.TrimRight(val, "/")
 			}
-			if !strings.HasSuffix(val, ".azconfig.io") {
-				val += ".azconfig.io"
+			if !strings.Contains(strings.TrimPrefix(val, "https://"), ".") {
+				val += c.cloud.AppConfigSuffix
 			}
 			chain.SetValue(val)
 		}
 	}).Print().Value()
-	config.GOCONFIG_APPCONFIG_KEYS = AsSlice().TrySetByEnv("GOCONFIG_APPCONFIG_KEYS").Print().Value()
+	c.settings.GOCONFIG_APPCONFIG_KEYS = c.AsSlice().TrySetValue(c.appconfigKeys).TrySetByEnv("GOCONFIG_APPCONFIG_KEYS").Print().Value()
+	c.settings.GOCONFIG_APPCONFIG_LABELS = c.AsSlice().TrySetByEnv("GOCONFIG_APPCONFIG_LABELS").Print().Value()
+	c.settings.GOCONFIG_APPCONFIG_NULL_LABEL = c.AsBool().TrySetByEnv("GOCONFIG_APPCONFIG_NULL_LABEL").DefaultTo(true).Print().Value()
+	c.settings.GOCONFIG_APPCONFIG_SENTINEL = c.AsString().TrySetByEnv("GOCONFIG_APPCONFIG_SENTINEL").Print().Value()
+	c.settings.GOCONFIG_APPCONFIG_CONCURRENCY = c.AsInt().TrySetByEnv("GOCONFIG_APPCONFIG_CONCURRENCY").DefaultTo(defaultConcurrency).Clamp(1, 64).Print().Value()
+	c.settings.GOCONFIG_EXPAND = c.AsBool().TrySetByEnv("GOCONFIG_EXPAND").DefaultTo(false).Print().Value()
+	c.settings.GOCONFIG_RESOLVE_ON_LOAD = c.AsBool().TrySetByEnv("GOCONFIG_RESOLVE_ON_LOAD").DefaultTo(false).Print().Value()
+	c.settings.GOCONFIG_KEYVAULT_CACHE_TTL = c.AsDuration().SetEmpty(-1).TrySetByEnv("GOCONFIG_KEYVAULT_CACHE_TTL").DefaultTo(5 * time.Minute).Print().Value()
+	c.settings.GOCONFIG_KEYVAULT_EXPIRED = c.AsString().TrySetByEnv("GOCONFIG_KEYVAULT_EXPIRED").ToLower().EnsureOneOf("warn", "fail").DefaultTo("warn").Print().Value()
+	c.settings.GOCONFIG_KEYVAULT_CONCURRENCY = c.AsInt().TrySetByEnv("GOCONFIG_KEYVAULT_CONCURRENCY").DefaultTo(defaultConcurrency).Clamp(1, 64).Print().Value()
 
 	// load from appconfig
-	if len(config.GOCONFIG_APPCONFIG) > 0 && len(config.GOCONFIG_APPCONFIG_KEYS) > 0 {
-		err = Apply(ctx, config.GOCONFIG_APPCONFIG_KEYS)
+	if len(c.settings.GOCONFIG_APPCONFIG) > 0 && len(c.settings.GOCONFIG_APPCONFIG_KEYS) > 0 {
+		err = c.Apply(ctx, c.settings.GOCONFIG_APPCONFIG_KEYS)
 		if err != nil {
 			return
 		}